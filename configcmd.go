@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"gosuite/services/config"
+)
+
+// exitInvalid is returned by `gosuite config validate` when it finds
+// problems.
+const exitInvalid = 1
+
+// runConfig runs the `gosuite config` subcommands and returns the process
+// exit code.
+func runConfig(configPath string, args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, "Usage: gosuite config show|validate")
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "show":
+		_, layers, err := config.GetConfigLayers(configPath)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return exitUsage
+		}
+
+		if err := layers.Show(stdout); err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return exitUsage
+		}
+
+		return exitOK
+
+	case "validate":
+		cfg, layers, err := config.GetConfigLayers(configPath)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return exitUsage
+		}
+
+		for _, problem := range cfg.Problems {
+			fmt.Fprintln(stderr, problem.Error())
+		}
+
+		if len(cfg.Problems) > 0 {
+			fmt.Fprintf(stderr, "%d problems found\n", len(cfg.Problems))
+			return exitInvalid
+		}
+
+		fmt.Fprintf(stdout, "%s is valid\n", strings.Join(layers.Paths, ", "))
+		return exitOK
+	}
+
+	fmt.Fprintf(stderr, "Error: unknown config command %q\n", args[0])
+	return exitUsage
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigValidate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	os.WriteFile(path, []byte("databases:\n  - name: local\n    prot: 3307\n"), 0o644)
+
+	var stdout, stderr bytes.Buffer
+	code := runConfig(path, []string{"validate"}, &stdout, &stderr)
+
+	if code != exitInvalid {
+		t.Errorf("Expected exit code %d, got %d", exitInvalid, code)
+	}
+	if want := path + `:3:5: unknown key "prot", did you mean "port"?`; !strings.Contains(stderr.String(), want) {
+		t.Errorf("Expected %q, got %q", want, stderr.String())
+	}
+
+	os.WriteFile(path, []byte("databases:\n  - name: local\n    port: 3307\n"), 0o644)
+	stdout.Reset()
+	stderr.Reset()
+
+	if code := runConfig(path, []string{"validate"}, &stdout, &stderr); code != exitOK {
+		t.Errorf("Expected exit code %d, got %d: %s", exitOK, code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "is valid") {
+		t.Errorf("Expected the config to be valid, got %q", stdout.String())
+	}
+}
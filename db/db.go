@@ -1,7 +1,9 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"time"
 
@@ -66,36 +68,112 @@ func (cp ConnectionPending) GetConnection() *sql.DB {
 	return nil
 }
 
-func Connect(info *config.DatabaseConfig) *sql.DB {
+// readOnlyConnector marks every pooled session as read-only, so the server
+// backs up the statement checks done in CheckReadOnly.
+type readOnlyConnector struct {
+	driver.Connector
+}
+
+func (c readOnlyConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	execer, ok := conn.(driver.ExecerContext)
+	if !ok {
+		conn.Close()
+		return nil, fmt.Errorf("driver does not support read-only sessions")
+	}
+
+	_, err = execer.ExecContext(ctx, "SET SESSION TRANSACTION READ ONLY", nil)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func Connect(info *config.DatabaseConfig) (*sql.DB, error) {
 	config := mysql.Config{
 		User:                    info.User,
 		Passwd:                  info.Password,
+		Net:                     "tcp",
 		Addr:                    fmt.Sprintf("%s:%d", info.Host, info.Port),
 		DBName:                  info.Database,
+		TLSConfig:               info.TLS,
+		Timeout:                 connectTimeout(info),
 		AllowNativePasswords:    true,
 		AllowCleartextPasswords: true,
 	}
 
-	db, err := sql.Open("mysql", config.FormatDSN())
+	connector, err := mysql.NewConnector(&config)
 	if err != nil {
-		panic(err.Error())
+		return nil, err
+	}
+
+	if info.ReadOnly {
+		connector = readOnlyConnector{connector}
+	}
+
+	db := sql.OpenDB(connector)
+
+	if info.MaxOpen > 0 {
+		db.SetMaxOpenConns(info.MaxOpen)
+	}
+	if info.MaxIdle > 0 {
+		db.SetMaxIdleConns(info.MaxIdle)
+	}
+	if info.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(info.ConnMaxLifetime)
 	}
 
-	err = db.Ping()
+	_, err = ping(db, info)
 	if err != nil {
-		panic(err.Error())
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func ConnectCmd(info *config.DatabaseConfig) tea.Cmd {
+	return func() tea.Msg {
+		db, err := Connect(info)
+		if err != nil {
+			return ConnectionError{config: info, reason: err.Error()}
+		}
+		return ConnectionSuccess{config: info, db: db}
+	}
+}
+
+// ExecuteRequestMsg asks for a query to be run against the active
+// connection, after it has passed the connection's safety checks.
+type ExecuteRequestMsg struct {
+	Query string
+}
+
+func RequestExecute(query string) tea.Cmd {
+	return func() tea.Msg {
+		return ExecuteRequestMsg{Query: query}
 	}
+}
 
-	return db
+type ExecuteError struct {
+	Query string
+	Err   error
 }
 
-type errMsg error
+func (ee ExecuteError) Error() string {
+	return ee.Err.Error()
+}
 
 func ExucuteSQLCmd(sql string, conn *sql.DB) tea.Cmd {
 	return func() tea.Msg {
 		result, err := ExecuteSQL(conn, sql)
 		if err != nil {
-			return errMsg(err)
+			return ExecuteError{Query: sql, Err: err}
 		}
 		return result
 	}
@@ -192,3 +270,15 @@ func GetTables(db *sql.DB) ([]string, error) {
 
 	return tables, nil
 }
+
+type TablesMsg struct {
+	Tables []string
+	Err    error
+}
+
+func GetTablesCmd(db *sql.DB) tea.Cmd {
+	return func() tea.Msg {
+		tables, err := GetTables(db)
+		return TablesMsg{Tables: tables, Err: err}
+	}
+}
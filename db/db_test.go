@@ -1,13 +1,34 @@
 package db
 
 import (
+	"database/sql"
 	"fmt"
 	"reflect"
 	"testing"
+
+	config "gosuite/services/config"
 )
 
+// connect uses the database from docker-compose.yml, skipping the test when
+// it isn't running.
+func connect(t *testing.T) *sql.DB {
+	db, err := Connect(&config.DatabaseConfig{
+		Name:     "test",
+		User:     "user",
+		Password: "password",
+		Host:     "localhost",
+		Port:     3306,
+		Database: "test",
+	})
+	if err != nil {
+		t.Skipf("Test database not available: %v", err)
+	}
+
+	return db
+}
+
 func TestExecute(t *testing.T) {
-	db := Connect()
+	db := connect(t)
 	defer db.Close()
 
 	// Banana
@@ -26,7 +47,7 @@ func TestExecute(t *testing.T) {
 }
 
 func TestGetTable(t *testing.T) {
-	db := Connect()
+	db := connect(t)
 	defer db.Close()
 
 	res, err := GetTables(db)
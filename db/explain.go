@@ -0,0 +1,75 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type ExplainResult struct {
+	Query string
+	// Analyze is set when Output is the EXPLAIN ANALYZE tree rather than
+	// EXPLAIN FORMAT=JSON.
+	Analyze bool
+	Output  string
+	Err     error
+}
+
+func ServerVersion(db *sql.DB) (string, error) {
+	var version string
+
+	err := db.QueryRow("SELECT VERSION()").Scan(&version)
+
+	return version, err
+}
+
+// SupportsExplainAnalyze reports whether version is MySQL 8.0.18 or newer.
+// MariaDB has its own ANALYZE statement with a different output.
+func SupportsExplainAnalyze(version string) bool {
+	if strings.Contains(strings.ToLower(version), "mariadb") {
+		return false
+	}
+
+	var major, minor, patch int
+	fmt.Sscanf(version, "%d.%d.%d", &major, &minor, &patch)
+
+	if major != 8 {
+		return major > 8
+	}
+	if minor != 0 {
+		return minor > 0
+	}
+	return patch >= 18
+}
+
+// Explain returns the plan for query. EXPLAIN ANALYZE runs the query, so it
+// is only used for read-only statements.
+func Explain(db *sql.DB, query string) (ExplainResult, error) {
+	query = strings.TrimSpace(query)
+	result := ExplainResult{Query: query}
+
+	version, err := ServerVersion(db)
+	if err != nil {
+		return result, err
+	}
+
+	statement := "EXPLAIN FORMAT=JSON " + query
+	if SupportsExplainAnalyze(version) && IsReadOnly(query) && strings.HasPrefix(strings.ToUpper(query), "SELECT") {
+		statement = "EXPLAIN ANALYZE " + query
+		result.Analyze = true
+	}
+
+	err = db.QueryRow(statement).Scan(&result.Output)
+
+	return result, err
+}
+
+func ExplainCmd(db *sql.DB, query string) tea.Cmd {
+	return func() tea.Msg {
+		result, err := Explain(db, query)
+		result.Err = err
+		return result
+	}
+}
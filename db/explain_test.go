@@ -0,0 +1,21 @@
+package db
+
+import "testing"
+
+func TestSupportsExplainAnalyze(t *testing.T) {
+	cases := map[string]bool{
+		"8.0.36":                 true,
+		"8.0.18":                 true,
+		"8.0.17":                 false,
+		"8.3.0":                  true,
+		"9.0.1":                  true,
+		"5.7.44-log":             false,
+		"10.11.6-MariaDB-1:10.1": false,
+	}
+
+	for version, expected := range cases {
+		if got := SupportsExplainAnalyze(version); got != expected {
+			t.Errorf("SupportsExplainAnalyze(%q): expected %v, got %v", version, expected, got)
+		}
+	}
+}
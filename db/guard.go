@@ -0,0 +1,220 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	config "gosuite/services/config"
+)
+
+var ErrReadOnly = errors.New("connection is read-only")
+
+var readOnlyVerbs = map[string]bool{
+	"SELECT":   true,
+	"SHOW":     true,
+	"EXPLAIN":  true,
+	"DESCRIBE": true,
+	"DESC":     true,
+}
+
+var destructiveVerbs = map[string]bool{
+	"DELETE":   true,
+	"UPDATE":   true,
+	"DROP":     true,
+	"TRUNCATE": true,
+	"ALTER":    true,
+	"RENAME":   true,
+	"REPLACE":  true,
+}
+
+// verb returns the first keyword of a statement, skipping any leading
+// parentheses as in "(SELECT ...) UNION (SELECT ...)".
+func verb(statement []Token) (string, int) {
+	for idx, token := range statement {
+		if token.Kind == SymbolToken && token.Value == "(" {
+			continue
+		}
+		return token.Keyword(), idx
+	}
+	return "", len(statement)
+}
+
+// mainVerb is the verb of a statement after any WITH list of common table
+// expressions, so WITH x AS (...) DELETE ... is a DELETE. It also returns
+// the statement from that verb on.
+func mainVerb(statement []Token) (string, []Token) {
+	keyword, idx := verb(statement)
+	if keyword != "WITH" {
+		return keyword, statement[idx:]
+	}
+
+	depth := 0
+	closed := false
+
+	for at := idx + 1; at < len(statement); at++ {
+		token := statement[at]
+
+		if token.Kind == SymbolToken {
+			switch token.Value {
+			case "(":
+				depth++
+			case ")":
+				depth--
+				closed = depth == 0
+			}
+			continue
+		}
+
+		if depth == 0 && closed && isStatementVerb(token.Keyword()) {
+			return token.Keyword(), statement[at:]
+		}
+	}
+
+	return keyword, statement[idx:]
+}
+
+func hasTopLevelKeyword(statement []Token, keyword string) bool {
+	depth := 0
+
+	for _, token := range statement {
+		if token.Kind == SymbolToken {
+			switch token.Value {
+			case "(":
+				depth++
+			case ")":
+				depth--
+			}
+			continue
+		}
+
+		if depth == 0 && token.Keyword() == keyword {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isReadOnlyStatement(statement []Token) bool {
+	keyword, statement := mainVerb(statement)
+
+	if !readOnlyVerbs[keyword] {
+		return false
+	}
+
+	switch keyword {
+	case "SELECT":
+		// SELECT ... INTO OUTFILE writes to the server's disk.
+		return !hasTopLevelKeyword(statement, "INTO")
+
+	case "EXPLAIN", "DESCRIBE", "DESC":
+		// EXPLAIN ANALYZE executes the statement, so whatever is being
+		// explained must be read-only itself.
+		analyze := false
+		rest := statement[1:]
+		for len(rest) > 0 {
+			switch {
+			case rest[0].Keyword() == "ANALYZE":
+				analyze = true
+				rest = rest[1:]
+			case rest[0].Keyword() == "EXTENDED", rest[0].Keyword() == "PARTITIONS":
+				rest = rest[1:]
+			case rest[0].Keyword() == "FORMAT" && len(rest) >= 3:
+				rest = rest[3:]
+			default:
+				// DESCRIBE posts vs EXPLAIN DELETE FROM posts
+				explained, _ := mainVerb(rest)
+				if analyze && isStatementVerb(explained) {
+					return isReadOnlyStatement(rest)
+				}
+				return true
+			}
+		}
+	}
+
+	return true
+}
+
+func isStatementVerb(keyword string) bool {
+	switch keyword {
+	case "INSERT", "WITH", "TABLE", "VALUES", "CALL", "DO", "SET", "LOAD":
+		return true
+	}
+	return destructiveVerbs[keyword] || readOnlyVerbs[keyword]
+}
+
+// IsReadOnly reports whether every statement in sql only reads data.
+func IsReadOnly(sql string) bool {
+	statements := SplitStatements(Tokenize(sql))
+
+	if len(statements) == 0 {
+		return false
+	}
+
+	for _, statement := range statements {
+		if !isReadOnlyStatement(statement) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsDestructive reports whether any statement in sql can remove or rewrite
+// existing data or schema.
+func IsDestructive(sql string) bool {
+	for _, statement := range SplitStatements(Tokenize(sql)) {
+		keyword, _ := mainVerb(statement)
+		if destructiveVerbs[keyword] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsUnfiltered reports whether sql contains an UPDATE or DELETE without a
+// WHERE clause, i.e. one that touches every row of a table.
+func IsUnfiltered(sql string) bool {
+	for _, statement := range SplitStatements(Tokenize(sql)) {
+		keyword, rest := mainVerb(statement)
+		if (keyword == "UPDATE" || keyword == "DELETE") && !hasTopLevelKeyword(rest, "WHERE") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func CheckReadOnly(cfg *config.DatabaseConfig, sql string) error {
+	if cfg == nil || !cfg.ReadOnly || IsReadOnly(sql) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: only SELECT, SHOW, EXPLAIN and DESCRIBE are allowed on %s", ErrReadOnly, cfg.Name)
+}
+
+func NeedsConfirmation(cfg *config.DatabaseConfig, sql string) bool {
+	return cfg != nil && cfg.IsProduction() && IsDestructive(sql)
+}
+
+// EstimateRows asks the optimizer how many rows sql would touch.
+func EstimateRows(db *sql.DB, query string) (int64, error) {
+	res, err := ExecuteSQL(db, "EXPLAIN "+strings.TrimRight(strings.TrimSpace(query), ";"))
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+
+	for _, row := range res.Rows {
+		var rows int64
+		if _, err := fmt.Sscan(fmt.Sprintf("%v", row["rows"]), &rows); err == nil {
+			total += rows
+		}
+	}
+
+	return total, nil
+}
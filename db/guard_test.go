@@ -0,0 +1,123 @@
+package db
+
+import (
+	"errors"
+	"testing"
+
+	config "gosuite/services/config"
+)
+
+func TestIsReadOnly(t *testing.T) {
+	cases := map[string]bool{
+		"SELECT * FROM posts":                              true,
+		"  select id from posts; show tables;":             true,
+		"(SELECT 1) UNION (SELECT 2)":                      true,
+		"SHOW FULL PROCESSLIST":                            true,
+		"DESCRIBE posts":                                   true,
+		"desc `posts`":                                     true,
+		"EXPLAIN FORMAT=JSON SELECT * FROM posts":          true,
+		"EXPLAIN ANALYZE SELECT * FROM posts":              true,
+		"EXPLAIN ANALYZE DELETE FROM posts":                false,
+		"SELECT * FROM posts INTO OUTFILE '/tmp/x'":        false,
+		"SELECT 1; DELETE FROM posts":                      false,
+		"-- SELECT\nDELETE FROM posts":                     false,
+		"/*!50000 DELETE */ FROM posts":                    false,
+		"SELECT 'DELETE FROM posts'":                       true,
+		"UPDATE posts SET title = 'SELECT'":                false,
+		"INSERT INTO posts (title) VALUES ('x')":           false,
+		"":                                                 false,
+		"SELECT (SELECT id INTO @x FROM posts) FROM dual":  true,
+		"WITH t AS (SELECT id FROM posts) SELECT * FROM t": true,
+		"WITH t AS (SELECT id FROM posts) SELECT * FROM t INTO OUTFILE '/tmp/x'":            false,
+		"WITH t AS (SELECT id FROM posts) DELETE FROM posts WHERE id IN (SELECT id FROM t)": false,
+		"WITH t AS (SELECT id FROM posts) UPDATE posts SET title = 'x'":                     false,
+		"EXPLAIN ANALYZE WITH t AS (SELECT 1) DELETE FROM posts":                            false,
+		"EXPLAIN ANALYZE WITH t AS (SELECT 1) SELECT * FROM t":                              true,
+	}
+
+	for sql, expected := range cases {
+		if got := IsReadOnly(sql); got != expected {
+			t.Errorf("IsReadOnly(%q): expected %v, got %v", sql, expected, got)
+		}
+	}
+}
+
+func TestIsUnfiltered(t *testing.T) {
+	cases := map[string]bool{
+		"DELETE FROM posts":                                                  true,
+		"DELETE FROM posts WHERE id = 1":                                     false,
+		"UPDATE posts SET title = 'where'":                                   true,
+		"UPDATE posts SET title = (SELECT name FROM x WHERE id=1)":           true,
+		"update posts set title = 'x' where id = 2":                          false,
+		"DROP TABLE posts":                                                   false,
+		"WITH old AS (SELECT id FROM posts WHERE id < 10) DELETE FROM posts": true,
+		"WITH old AS (SELECT id FROM posts) DELETE FROM posts WHERE id IN (SELECT id FROM old)":        false,
+		"WITH RECURSIVE n (i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < 5) SELECT * FROM n": false,
+	}
+
+	for sql, expected := range cases {
+		if got := IsUnfiltered(sql); got != expected {
+			t.Errorf("IsUnfiltered(%q): expected %v, got %v", sql, expected, got)
+		}
+	}
+}
+
+func TestCheckReadOnly(t *testing.T) {
+	cfg := &config.DatabaseConfig{Name: "replica", ReadOnly: true}
+
+	if err := CheckReadOnly(cfg, "SELECT * FROM posts"); err != nil {
+		t.Errorf("Expected SELECT to be allowed, got %v", err)
+	}
+
+	if err := CheckReadOnly(cfg, "TRUNCATE posts"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly, got %v", err)
+	}
+
+	cfg.ReadOnly = false
+
+	if err := CheckReadOnly(cfg, "TRUNCATE posts"); err != nil {
+		t.Errorf("Expected writable connection to allow TRUNCATE, got %v", err)
+	}
+}
+
+func TestNeedsConfirmation(t *testing.T) {
+	prod := &config.DatabaseConfig{Environment: "production"}
+	dev := &config.DatabaseConfig{}
+
+	if !NeedsConfirmation(prod, "DROP TABLE posts") {
+		t.Errorf("Expected DROP on production to need confirmation")
+	}
+
+	if !NeedsConfirmation(prod, "WITH old AS (SELECT id FROM posts) DELETE FROM posts") {
+		t.Errorf("Expected a DELETE after a WITH list on production to need confirmation")
+	}
+
+	if NeedsConfirmation(prod, "WITH ids AS (SELECT id FROM posts) SELECT * FROM ids") {
+		t.Errorf("Expected a SELECT after a WITH list not to need confirmation")
+	}
+
+	if NeedsConfirmation(prod, "SELECT * FROM posts") {
+		t.Errorf("Expected SELECT on production not to need confirmation")
+	}
+
+	if NeedsConfirmation(dev, "DROP TABLE posts") {
+		t.Errorf("Expected DROP outside production not to need confirmation")
+	}
+}
+
+func TestStatementAt(t *testing.T) {
+	sql := "SELECT 1;\nSELECT 'a;b' FROM posts;\n\nSHOW TABLES"
+
+	cases := map[int]string{
+		0:  "SELECT 1",
+		12: "SELECT 'a;b' FROM posts",
+		37: "SHOW TABLES",
+		99: "SHOW TABLES",
+	}
+
+	for offset, expected := range cases {
+		if got := StatementAt(sql, offset); got != expected {
+			t.Errorf("StatementAt(%d): expected %q, got %q", offset, expected, got)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	config "gosuite/services/config"
+)
+
+const (
+	// PingInterval is how often an open connection is checked.
+	PingInterval          = 10 * time.Second
+	defaultConnectTimeout = 10 * time.Second
+	maxBackoff            = 30 * time.Second
+)
+
+func connectTimeout(info *config.DatabaseConfig) time.Duration {
+	if info.ConnectTimeout > 0 {
+		return info.ConnectTimeout
+	}
+	return defaultConnectTimeout
+}
+
+// ErrPoolBusy is a ping skipped because every connection the pool may open
+// is running a query, which says nothing about the server being gone.
+var ErrPoolBusy = errors.New("every connection is busy")
+
+func poolBusy(db *sql.DB) bool {
+	stats := db.Stats()
+	return stats.MaxOpenConnections > 0 && stats.InUse >= stats.MaxOpenConnections
+}
+
+func ping(db *sql.DB, info *config.DatabaseConfig) (time.Duration, error) {
+	if poolBusy(db) {
+		return 0, ErrPoolBusy
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout(info))
+	defer cancel()
+
+	start := time.Now()
+	err := db.PingContext(ctx)
+
+	// A query that took the last connection meanwhile keeps the ping
+	// waiting until it times out.
+	if errors.Is(err, context.DeadlineExceeded) && poolBusy(db) {
+		err = ErrPoolBusy
+	}
+
+	return time.Since(start), err
+}
+
+// Backoff is the wait before the given reconnect attempt, doubling from a
+// second up to 30s.
+func Backoff(attempt int) time.Duration {
+	wait := time.Second
+	for idx := 1; idx < attempt && wait < maxBackoff; idx++ {
+		wait *= 2
+	}
+	return min(wait, maxBackoff)
+}
+
+// ConnectionLost is a connection that stopped answering, waiting to
+// reconnect.
+type ConnectionLost struct {
+	config *config.DatabaseConfig
+	reason string
+	// Attempt is the number of the next reconnect.
+	Attempt int
+}
+
+func (cl ConnectionLost) Status() string {
+	return fmt.Sprintf("Reconnecting in %s: %s", Backoff(cl.Attempt), cl.reason)
+}
+
+func (cl ConnectionLost) GetConfig() *config.DatabaseConfig {
+	return cl.config
+}
+
+func (cl ConnectionLost) GetConnection() *sql.DB {
+	return nil
+}
+
+// PingMsg is the result of a keepalive ping of DB.
+type PingMsg struct {
+	DB      *sql.DB
+	Latency time.Duration
+	Err     error
+}
+
+// PingCmd pings db once after has passed.
+func PingCmd(db *sql.DB, info *config.DatabaseConfig, after time.Duration) tea.Cmd {
+	return tea.Tick(after, func(time.Time) tea.Msg {
+		latency, err := ping(db, info)
+		return PingMsg{DB: db, Latency: latency, Err: err}
+	})
+}
+
+// Lost closes the connection that failed a ping and returns it waiting for
+// its first reconnect.
+func Lost(conn Connection, err error) ConnectionLost {
+	if db := conn.GetConnection(); db != nil {
+		db.Close()
+	}
+	return ConnectionLost{config: conn.GetConfig(), reason: err.Error(), Attempt: 1}
+}
+
+// ReconnectCmd waits out the backoff of lost and connects again.
+func ReconnectCmd(lost ConnectionLost) tea.Cmd {
+	return tea.Tick(Backoff(lost.Attempt), func(time.Time) tea.Msg {
+		db, err := Connect(lost.config)
+		if err != nil {
+			return ConnectionLost{config: lost.config, reason: err.Error(), Attempt: lost.Attempt + 1}
+		}
+		return ConnectionSuccess{config: lost.config, db: db}
+	})
+}
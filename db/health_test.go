@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	config "gosuite/services/config"
+)
+
+func TestBackoff(t *testing.T) {
+	for attempt, want := range map[int]time.Duration{
+		1:  time.Second,
+		2:  2 * time.Second,
+		4:  8 * time.Second,
+		6:  30 * time.Second,
+		50: 30 * time.Second,
+	} {
+		if got := Backoff(attempt); got != want {
+			t.Errorf("Expected %v for attempt %d, got %v", want, attempt, got)
+		}
+	}
+}
+
+func TestLost(t *testing.T) {
+	info := &config.DatabaseConfig{Name: "local"}
+
+	lost := Lost(ConnectionPending{Config: info}, errors.New("invalid connection"))
+
+	if lost.GetConfig() != info || lost.GetConnection() != nil {
+		t.Errorf("Expected the lost connection to keep its config, got %+v", lost)
+	}
+	if want := "Reconnecting in 1s: invalid connection"; lost.Status() != want {
+		t.Errorf("Expected %q, got %q", want, lost.Status())
+	}
+}
+
+func TestConnectTimeout(t *testing.T) {
+	if got := connectTimeout(&config.DatabaseConfig{}); got != defaultConnectTimeout {
+		t.Errorf("Expected %v, got %v", defaultConnectTimeout, got)
+	}
+	if got := connectTimeout(&config.DatabaseConfig{ConnectTimeout: 2 * time.Second}); got != 2*time.Second {
+		t.Errorf("Expected 2s, got %v", got)
+	}
+}
+
+// idleDriver hands out connections that are never used for anything.
+type idleDriver struct{}
+
+func (d idleDriver) Connect(context.Context) (driver.Conn, error) { return d, nil }
+func (d idleDriver) Driver() driver.Driver                        { return nil }
+func (d idleDriver) Prepare(string) (driver.Stmt, error)          { return nil, driver.ErrSkip }
+func (d idleDriver) Close() error                                 { return nil }
+func (d idleDriver) Begin() (driver.Tx, error)                    { return nil, driver.ErrSkip }
+
+func TestPingBusyPool(t *testing.T) {
+	pool := sql.OpenDB(idleDriver{})
+	pool.SetMaxOpenConns(1)
+	info := &config.DatabaseConfig{ConnectTimeout: 50 * time.Millisecond}
+
+	// A long query holds the only connection.
+	conn, err := pool.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ping(pool, info); !errors.Is(err, ErrPoolBusy) {
+		t.Errorf("Expected ErrPoolBusy while the query runs, got %v", err)
+	}
+
+	conn.Close()
+
+	if _, err := ping(pool, info); err != nil {
+		t.Errorf("Expected the ping to go through once the query is done, got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type Process struct {
+	ID      int64
+	User    string
+	Host    string
+	DB      string
+	Command string
+	Time    int64
+	State   string
+	Info    string
+}
+
+type ProcessListMsg struct {
+	Processes []Process
+	Err       error
+}
+
+type KillResult struct {
+	ID         int64
+	Connection bool
+	Err        error
+}
+
+// discard takes a column that isn't part of Process.
+type discard struct{}
+
+func (discard) Scan(interface{}) error { return nil }
+
+// processFields returns the scan targets for the named columns of SHOW FULL
+// PROCESSLIST, which differ between servers: MariaDB adds Progress, for
+// one. build reads the scanned values into a Process.
+func processFields(columns []string) ([]interface{}, func() Process) {
+	var id, time sql.NullInt64
+	var user, host, database, command, state, info sql.NullString
+
+	targets := make([]interface{}, len(columns))
+	for idx, column := range columns {
+		switch strings.ToLower(column) {
+		case "id":
+			targets[idx] = &id
+		case "user":
+			targets[idx] = &user
+		case "host":
+			targets[idx] = &host
+		case "db":
+			targets[idx] = &database
+		case "command":
+			targets[idx] = &command
+		case "time":
+			targets[idx] = &time
+		case "state":
+			targets[idx] = &state
+		case "info":
+			targets[idx] = &info
+		default:
+			targets[idx] = discard{}
+		}
+	}
+
+	return targets, func() Process {
+		return Process{
+			ID:      id.Int64,
+			User:    user.String,
+			Host:    host.String,
+			DB:      database.String,
+			Command: command.String,
+			Time:    time.Int64,
+			State:   state.String,
+			Info:    info.String,
+		}
+	}
+}
+
+func GetProcessList(db *sql.DB) ([]Process, error) {
+	processes := make([]Process, 0)
+
+	rows, err := db.Query("SHOW FULL PROCESSLIST")
+	if err != nil {
+		return processes, err
+	}
+
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return processes, err
+	}
+
+	targets, build := processFields(columns)
+
+	for rows.Next() {
+		if err := rows.Scan(targets...); err != nil {
+			return processes, err
+		}
+
+		processes = append(processes, build())
+	}
+
+	return processes, rows.Err()
+}
+
+func ProcessListCmd(db *sql.DB) tea.Cmd {
+	return func() tea.Msg {
+		processes, err := GetProcessList(db)
+		return ProcessListMsg{Processes: processes, Err: err}
+	}
+}
+
+// KillStatement returns the statement that kills the running query of a
+// session, or the whole session when connection is set.
+func KillStatement(id int64, connection bool) string {
+	if connection {
+		return fmt.Sprintf("KILL CONNECTION %d", id)
+	}
+	return fmt.Sprintf("KILL QUERY %d", id)
+}
+
+func KillCmd(db *sql.DB, id int64, connection bool) tea.Cmd {
+	return func() tea.Msg {
+		_, err := db.Exec(KillStatement(id, connection))
+		return KillResult{ID: id, Connection: connection, Err: err}
+	}
+}
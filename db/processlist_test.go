@@ -0,0 +1,29 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestProcessFields(t *testing.T) {
+	// MariaDB's SHOW FULL PROCESSLIST ends with a Progress column.
+	columns := []string{"Id", "User", "Host", "db", "Command", "Time", "State", "Info", "Progress"}
+	values := []interface{}{int64(7), "app", "10.0.0.2:5123", nil, "Query", int64(12), "Sending data", "SELECT 1", "0.000"}
+
+	targets, build := processFields(columns)
+
+	if len(targets) != len(columns) {
+		t.Fatalf("Expected %d targets, got %d", len(columns), len(targets))
+	}
+
+	for idx, target := range targets {
+		if err := target.(sql.Scanner).Scan(values[idx]); err != nil {
+			t.Fatalf("Expected %s to scan, got %v", columns[idx], err)
+		}
+	}
+
+	want := Process{ID: 7, User: "app", Host: "10.0.0.2:5123", Command: "Query", Time: 12, State: "Sending data", Info: "SELECT 1"}
+	if got := build(); got != want {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+}
@@ -0,0 +1,42 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+func QuoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+var valueEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`'`, `''`,
+	"\x00", `\0`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\x1a", `\Z`,
+)
+
+// QuoteValue renders a value scanned by ExecuteSQL as a SQL literal, so the
+// generated statements can be shown and edited in the query pane.
+func QuoteValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprintf("%v", v)
+	case time.Time:
+		return "'" + v.Format("2006-01-02 15:04:05.999999") + "'"
+	case []byte:
+		return "'" + valueEscaper.Replace(string(v)) + "'"
+	default:
+		return "'" + valueEscaper.Replace(fmt.Sprintf("%v", v)) + "'"
+	}
+}
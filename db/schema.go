@@ -0,0 +1,115 @@
+package db
+
+import (
+	"database/sql"
+	"strings"
+)
+
+// GetPrimaryKey returns the primary key columns of a table in the current
+// database, in key order.
+func GetPrimaryKey(db *sql.DB, table string) ([]string, error) {
+	columns := make([]string, 0)
+
+	rows, err := db.Query(`
+		SELECT COLUMN_NAME
+		FROM information_schema.KEY_COLUMN_USAGE
+		WHERE TABLE_SCHEMA = DATABASE()
+		  AND TABLE_NAME = ?
+		  AND CONSTRAINT_NAME = 'PRIMARY'
+		ORDER BY ORDINAL_POSITION`, table)
+	if err != nil {
+		return columns, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return columns, err
+		}
+		columns = append(columns, column)
+	}
+
+	return columns, rows.Err()
+}
+
+type ForeignKey struct {
+	Name              string
+	Table             string
+	Columns           []string
+	ReferencedTable   string
+	ReferencedColumns []string
+}
+
+func (fk ForeignKey) String() string {
+	return fk.Table + "(" + strings.Join(fk.Columns, ", ") + ") → " +
+		fk.ReferencedTable + "(" + strings.Join(fk.ReferencedColumns, ", ") + ")"
+}
+
+// GetForeignKeys returns every foreign key in the current database that
+// either belongs to table or references it.
+func GetForeignKeys(db *sql.DB, table string) ([]ForeignKey, error) {
+	keys := make([]ForeignKey, 0)
+
+	rows, err := db.Query(`
+		SELECT CONSTRAINT_NAME, TABLE_NAME, COLUMN_NAME, REFERENCED_TABLE_NAME, REFERENCED_COLUMN_NAME
+		FROM information_schema.KEY_COLUMN_USAGE
+		WHERE TABLE_SCHEMA = DATABASE()
+		  AND REFERENCED_TABLE_SCHEMA = DATABASE()
+		  AND (TABLE_NAME = ? OR REFERENCED_TABLE_NAME = ?)
+		ORDER BY TABLE_NAME, CONSTRAINT_NAME, ORDINAL_POSITION`, table, table)
+	if err != nil {
+		return keys, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, childTable, column, parentTable, parentColumn string
+		if err := rows.Scan(&name, &childTable, &column, &parentTable, &parentColumn); err != nil {
+			return keys, err
+		}
+
+		// Composite keys come back as one row per column.
+		if last := len(keys) - 1; last >= 0 && keys[last].Name == name && keys[last].Table == childTable {
+			keys[last].Columns = append(keys[last].Columns, column)
+			keys[last].ReferencedColumns = append(keys[last].ReferencedColumns, parentColumn)
+			continue
+		}
+
+		keys = append(keys, ForeignKey{
+			Name:              name,
+			Table:             childTable,
+			Columns:           []string{column},
+			ReferencedTable:   parentTable,
+			ReferencedColumns: []string{parentColumn},
+		})
+	}
+
+	return keys, rows.Err()
+}
+
+type TableKeys struct {
+	Table       string
+	PrimaryKey  []string
+	ForeignKeys []ForeignKey
+}
+
+func GetTableKeys(db *sql.DB, table string) (TableKeys, error) {
+	keys := TableKeys{Table: table}
+
+	primaryKey, err := GetPrimaryKey(db, table)
+	if err != nil {
+		return keys, err
+	}
+	keys.PrimaryKey = primaryKey
+
+	foreignKeys, err := GetForeignKeys(db, table)
+	if err != nil {
+		return keys, err
+	}
+	keys.ForeignKeys = foreignKeys
+
+	return keys, nil
+}
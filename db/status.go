@@ -0,0 +1,96 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type ServerStatus struct {
+	Version string
+	// Variables holds the output of SHOW GLOBAL STATUS.
+	Variables map[string]string
+	Replica   bool
+	// ReplicaLag is the replica's Seconds_Behind_Source, which is NULL while
+	// replication is stopped.
+	ReplicaLag sql.NullInt64
+	SampledAt  time.Time
+}
+
+type StatusMsg struct {
+	Status ServerStatus
+	Err    error
+}
+
+func getReplicaLag(db *sql.DB) (bool, sql.NullInt64, error) {
+	res, err := ExecuteSQL(db, "SHOW REPLICA STATUS")
+	if err != nil {
+		// Servers older than MySQL 8.0.22 only know the old name.
+		res, err = ExecuteSQL(db, "SHOW SLAVE STATUS")
+	}
+	if err != nil || len(res.Rows) == 0 {
+		return false, sql.NullInt64{}, err
+	}
+
+	row := res.Rows[0]
+
+	for _, column := range []string{"Seconds_Behind_Source", "Seconds_Behind_Master"} {
+		value, ok := row[column]
+		if !ok {
+			continue
+		}
+
+		var lag sql.NullInt64
+		if value != nil {
+			lag.Scan(value)
+		}
+		return true, lag, nil
+	}
+
+	return true, sql.NullInt64{}, nil
+}
+
+func GetServerStatus(db *sql.DB) (ServerStatus, error) {
+	status := ServerStatus{
+		Variables: make(map[string]string),
+		SampledAt: time.Now(),
+	}
+
+	version, err := ServerVersion(db)
+	if err != nil {
+		return status, err
+	}
+	status.Version = version
+
+	rows, err := db.Query("SHOW GLOBAL STATUS")
+	if err != nil {
+		return status, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, value string
+		if err := rows.Scan(&name, &value); err != nil {
+			return status, err
+		}
+		status.Variables[name] = value
+	}
+	if err := rows.Err(); err != nil {
+		return status, err
+	}
+
+	// Replication status needs REPLICATION CLIENT, without it the
+	// dashboard just leaves out the lag.
+	status.Replica, status.ReplicaLag, _ = getReplicaLag(db)
+
+	return status, nil
+}
+
+func ServerStatusCmd(db *sql.DB) tea.Cmd {
+	return func() tea.Msg {
+		status, err := GetServerStatus(db)
+		return StatusMsg{Status: status, Err: err}
+	}
+}
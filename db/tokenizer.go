@@ -0,0 +1,195 @@
+package db
+
+import (
+	"strings"
+	"unicode"
+)
+
+type TokenKind int
+
+const (
+	WordToken TokenKind = iota
+	StringToken
+	IdentifierToken
+	NumberToken
+	SymbolToken
+)
+
+type Token struct {
+	Kind  TokenKind
+	Value string
+	// Start and End are rune offsets of the token in the tokenized SQL.
+	Start int
+	End   int
+}
+
+// Keyword returns the upper-cased value of a bare word, or an empty string
+// for any other kind of token.
+func (t Token) Keyword() string {
+	if t.Kind != WordToken {
+		return ""
+	}
+	return strings.ToUpper(t.Value)
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Tokenize splits MySQL flavoured SQL into tokens, dropping whitespace and
+// comments. Executable comments (/*! ... */) are tokenized as regular SQL
+// since the server runs them.
+func Tokenize(sql string) []Token {
+	runes := []rune(sql)
+	return tokenize(runes, 0, len(runes))
+}
+
+func tokenize(runes []rune, from int, to int) []Token {
+	tokens := make([]Token, 0)
+
+	for i := from; i < to; {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '#' || (r == '-' && i+1 < to && runes[i+1] == '-'):
+			for i < to && runes[i] != '\n' {
+				i++
+			}
+
+		case r == '/' && i+1 < to && runes[i+1] == '*':
+			executable := i+2 < to && runes[i+2] == '!'
+
+			end := i + 2
+			for end+1 < to && !(runes[end] == '*' && runes[end+1] == '/') {
+				end++
+			}
+
+			if executable {
+				// Skip the optional version number, e.g. /*!50000 ... */
+				start := i + 3
+				for start < end && unicode.IsDigit(runes[start]) {
+					start++
+				}
+				tokens = append(tokens, tokenize(runes, start, end)...)
+			}
+
+			i = end + 2
+
+		case r == '\'' || r == '"' || r == '`':
+			start := i
+			quote := r
+			var value strings.Builder
+			i++
+
+			for i < to {
+				if runes[i] == '\\' && quote != '`' && i+1 < to {
+					value.WriteRune(runes[i+1])
+					i += 2
+					continue
+				}
+				if runes[i] == quote {
+					if i+1 < to && runes[i+1] == quote {
+						value.WriteRune(quote)
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				value.WriteRune(runes[i])
+				i++
+			}
+
+			kind := StringToken
+			if quote == '`' {
+				kind = IdentifierToken
+			}
+			tokens = append(tokens, Token{Kind: kind, Value: value.String(), Start: start, End: i})
+
+		case unicode.IsDigit(r):
+			start := i
+			for i < to && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, Token{Kind: NumberToken, Value: string(runes[start:i]), Start: start, End: i})
+
+		case isWordRune(r):
+			start := i
+			for i < to && isWordRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, Token{Kind: WordToken, Value: string(runes[start:i]), Start: start, End: i})
+
+		default:
+			tokens = append(tokens, Token{Kind: SymbolToken, Value: string(r), Start: i, End: i + 1})
+			i++
+		}
+	}
+
+	return tokens
+}
+
+// SplitStatements groups tokens into statements separated by semicolons.
+func SplitStatements(tokens []Token) [][]Token {
+	statements := make([][]Token, 0)
+	current := make([]Token, 0)
+
+	for _, token := range tokens {
+		if token.Kind == SymbolToken && token.Value == ";" {
+			if len(current) > 0 {
+				statements = append(statements, current)
+			}
+			current = make([]Token, 0)
+			continue
+		}
+		current = append(current, token)
+	}
+
+	if len(current) > 0 {
+		statements = append(statements, current)
+	}
+
+	return statements
+}
+
+// Statements splits sql on top-level semicolons, returning the text of each
+// non-empty statement without its terminator.
+func Statements(sql string) []string {
+	runes := []rune(sql)
+	statements := make([]string, 0)
+
+	for _, statement := range SplitStatements(Tokenize(sql)) {
+		start := statement[0].Start
+		end := statement[len(statement)-1].End
+		statements = append(statements, string(runes[start:end]))
+	}
+
+	return statements
+}
+
+// StatementAt returns the statement surrounding the rune offset, falling
+// back to the closest statement before it.
+func StatementAt(sql string, offset int) string {
+	runes := []rune(sql)
+	found := ""
+
+	for _, statement := range SplitStatements(Tokenize(sql)) {
+		start := statement[0].Start
+		end := statement[len(statement)-1].End
+
+		if start > offset && found != "" {
+			break
+		}
+
+		found = string(runes[start:end])
+
+		if offset <= end {
+			break
+		}
+	}
+
+	return found
+}
@@ -0,0 +1,46 @@
+package design
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// MouseMsg is a mouse event over a pane, in the pane's own coordinates and
+// with the pane's size, so it can work out which element was hit.
+type MouseMsg struct {
+	tea.MouseMsg
+	Width  int
+	Height int
+}
+
+// Content returns the pointer position inside the content area, or false
+// when it is over the border or the padding.
+func (m MouseMsg) Content() (int, int, bool) {
+	contentWidth, contentHeight := ContentSize(m.Width, m.Height)
+
+	x := m.X - 1 - padding(m.Width-2)
+	y := m.Y - 1
+
+	return x, y, x >= 0 && y >= 0 && x < contentWidth && y < contentHeight
+}
+
+func (m MouseMsg) Clicked() bool {
+	return m.Action == tea.MouseActionPress && m.Button == tea.MouseButtonLeft
+}
+
+// Scroll is -1 for the wheel going up, 1 going down and 0 otherwise.
+func (m MouseMsg) Scroll() int {
+	switch m.Button {
+	case tea.MouseButtonWheelUp:
+		return -1
+	case tea.MouseButtonWheelDown:
+		return 1
+	}
+	return 0
+}
+
+// ScrollStart is the first of visible rows to draw so the cursor stays in
+// view.
+func ScrollStart(cursor int, visible int) int {
+	if cursor >= visible && visible > 0 {
+		return cursor - visible + 1
+	}
+	return 0
+}
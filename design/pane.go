@@ -0,0 +1,113 @@
+package design
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Pane is a box with its number and title set into the top border. It
+// always renders exactly the size it is given, clipping the content.
+type Pane struct {
+	Index    int
+	Title    string
+	Selected bool
+}
+
+func padding(innerWidth int) int {
+	if innerWidth >= 6 {
+		return 1
+	}
+	return 0
+}
+
+// ContentSize is the room left for content inside a width × height pane.
+func ContentSize(width int, height int) (int, int) {
+	innerWidth := width - 2
+	contentWidth := innerWidth - 2*padding(innerWidth)
+
+	return max(contentWidth, 0), max(height-2, 0)
+}
+
+func blank(width int, height int) string {
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+
+	line := strings.Repeat(" ", width)
+	lines := make([]string, height)
+	for idx := range lines {
+		lines[idx] = line
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width <= 1 {
+		return string(runes[:max(width, 0)])
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+func (p Pane) topBorder(innerWidth int, border lipgloss.Style) string {
+	title := truncate(fmt.Sprintf("[%d] %s", p.Index, p.Title), innerWidth-2)
+	if innerWidth < 3 {
+		title = ""
+	}
+
+	rest := innerWidth - len([]rune(title))
+	if title != "" {
+		rest--
+		title = border.Copy().Bold(p.Selected).Render(title)
+	}
+
+	line := border.Render("╭")
+	if title != "" {
+		line += border.Render("─") + title
+	}
+
+	return line + border.Render(strings.Repeat("─", rest)+"╮")
+}
+
+func (p Pane) Render(width int, height int, content string) string {
+	if width < 2 || height < 2 {
+		return blank(width, height)
+	}
+
+	border := lipgloss.NewStyle().Foreground(GetBorderColor(p.Selected))
+	innerWidth := width - 2
+	pad := strings.Repeat(" ", padding(innerWidth))
+	contentWidth, contentHeight := ContentSize(width, height)
+
+	// A zero MaxWidth or MaxHeight means no limit to lipgloss.
+	bodyLines := []string{}
+	if contentWidth > 0 && contentHeight > 0 {
+		body := lipgloss.NewStyle().MaxWidth(contentWidth).MaxHeight(contentHeight).Render(content)
+		bodyLines = strings.Split(body, "\n")
+	}
+
+	lines := make([]string, 0, height)
+	lines = append(lines, p.topBorder(innerWidth, border))
+
+	side := border.Render("│")
+	for idx := 0; idx < contentHeight; idx++ {
+		line := ""
+		if idx < len(bodyLines) {
+			line = bodyLines[idx]
+		}
+		if gap := contentWidth - lipgloss.Width(line); gap > 0 {
+			line += strings.Repeat(" ", gap)
+		}
+		lines = append(lines, side+pad+line+pad+side)
+	}
+
+	lines = append(lines, border.Render("╰"+strings.Repeat("─", innerWidth)+"╯"))
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,42 @@
+package design
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestPaneSize(t *testing.T) {
+	pane := Pane{Index: 4, Title: "Results", Selected: true}
+	content := "a line that is much wider than the pane\nsecond\nthird\nfourth"
+
+	for _, size := range [][2]int{{40, 10}, {12, 4}, {6, 3}, {3, 2}, {2, 2}, {1, 1}, {0, 0}} {
+		width, height := size[0], size[1]
+		rendered := pane.Render(width, height, content)
+
+		if got := lipgloss.Height(rendered); rendered != "" && got != height {
+			t.Errorf("Expected height %d, got %d for %dx%d", height, got, width, height)
+		}
+
+		for _, line := range strings.Split(rendered, "\n") {
+			if got := lipgloss.Width(line); rendered != "" && got != width {
+				t.Errorf("Expected width %d, got %d for %dx%d: %q", width, got, width, height, line)
+			}
+		}
+	}
+}
+
+func TestPaneTitle(t *testing.T) {
+	top := strings.Split(Pane{Index: 2, Title: "Tables"}.Render(20, 5, ""), "\n")[0]
+
+	if !strings.Contains(top, "[2] Tables") {
+		t.Errorf("Expected the title in the top border, got %q", top)
+	}
+
+	top = strings.Split(Pane{Index: 2, Title: "Tables"}.Render(10, 5, ""), "\n")[0]
+
+	if !strings.Contains(top, "[2] T…") {
+		t.Errorf("Expected a truncated title, got %q", top)
+	}
+}
@@ -1,85 +1,59 @@
 package design
 
 import (
-	"regexp"
-	"strconv"
-	"strings"
-
 	"github.com/charmbracelet/lipgloss"
+
+	theme "gosuite/theme"
 )
 
+var production bool
+
+// SetProduction switches every pane to the red border theme while connected
+// to a production database.
+func SetProduction(enabled bool) {
+	production = enabled
+}
+
 func GetBorderColor(selected bool) lipgloss.TerminalColor {
+	if production {
+		if selected {
+			return theme.Current.ProductionBorderSelected
+		}
+		return theme.Current.ProductionBorder
+	}
 	if selected {
-		return lipgloss.Color("50")
+		return theme.Current.BorderSelected
 	}
-	return lipgloss.Color("255")
+	return theme.Current.Border
 }
 
-// stripANSI removes ANSI escape sequences from a string.
-func stripANSI(str string) string {
-	re := regexp.MustCompile(`\x1b\[[0-9;]*m`)
-	return re.ReplaceAllString(str, "")
-}
+var sparkRunes = []rune("▁▂▃▄▅▆▇█")
 
-func addTextToBorder(content string, index int, text string, selected bool) string {
-	lines := strings.Split(content, "\n")
-	if len(lines) < 1 {
-		return content // If there's no content, just return it unchanged.
+// Sparkline draws the last width values scaled between zero and the
+// largest value.
+func Sparkline(values []float64, width int) string {
+	if width <= 0 {
+		return ""
 	}
-
-	// Render the insertion text with optional bold styling.
-	insertionText := lipgloss.NewStyle().
-		Bold(selected).
-		Foreground(GetBorderColor(selected)).
-		Render("[" + strconv.Itoa(index) + "] " + text)
-
-	// Calculate the visible length of the insertionText by stripping ANSI codes.
-	visibleInsertionLength := len(stripANSI(insertionText))
-
-	magicNumber := 6
-
-	// Process the first line to find the third visible character position.
-	strippedFirstLine := stripANSI(lines[0])
-	// Ensuring not to exceed the length of the stripped line.
-	if len(strippedFirstLine) < magicNumber {
-		return content // Not enough length for insertion.
+	if len(values) > width {
+		values = values[len(values)-width:]
 	}
 
-	// The third visible character position in the stripped content.
-	// Adjust 'insertAt' dynamically based on your requirements.
-	insertAt := magicNumber + len([]rune(strippedFirstLine)[:magicNumber])
-
-	// Convert the original first line (with ANSI codes) to runes.
-	runes := []rune(lines[0])
-	// Calculate the cut index; adjust dynamically if necessary.
-	cutIndex := insertAt + visibleInsertionLength
-	if cutIndex > len(runes) {
-		cutIndex = len(runes) // Ensure cutIndex does not exceed the line length.
+	max := 0.0
+	for _, value := range values {
+		if value > max {
+			max = value
+		}
 	}
 
-	beforeInsertion := string(runes[:insertAt])
-	afterInsertion := string(runes[cutIndex:])
-
-	// Reassemble the modified top border.
-	lines[0] = beforeInsertion + insertionText + afterInsertion
-
-	return strings.Join(lines, "\n")
-}
-
-func CreatePane(
-	index int,
-	title string,
-	selected bool,
-	width int,
-	height int,
-	content string,
-) string {
-	styledContent := lipgloss.NewStyle().
-		Padding(1, 1).
-		Border(lipgloss.RoundedBorder()).
-		MarginRight(1).
-		Width(width).
-		Height(height).BorderForeground(GetBorderColor(selected)).Render(content)
+	line := make([]rune, len(values))
+	for idx, value := range values {
+		level := 0
+		if max > 0 && value > 0 {
+			level = int(value / max * float64(len(sparkRunes)-1))
+		}
+		line[idx] = sparkRunes[level]
+	}
 
-	return addTextToBorder(styledContent, index, title, selected)
+	return string(line)
 }
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+
+	db "gosuite/db"
+	"gosuite/services/config"
+)
+
+// Exit codes of the non-interactive commands.
+const (
+	exitOK         = 0
+	exitSQLError   = 1
+	exitUsage      = 2
+	exitConnection = 3
+	exitRefused    = 4
+)
+
+type execOptions struct {
+	config     string
+	connection string
+	sql        string
+	file       string
+	format     string
+	yes        bool
+}
+
+func parseExecArgs(args []string, stderr io.Writer, terminal bool) (execOptions, error) {
+	opts := execOptions{}
+
+	flags := flag.NewFlagSet("exec", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: gosuite exec [-c connection] [-e SQL | -f script.sql] [--format table|csv|json|ndjson] [--yes]")
+		fmt.Fprintln(stderr, "SQL is read from stdin when neither -e nor -f is given.")
+		flags.PrintDefaults()
+	}
+
+	flags.StringVar(&opts.config, "config", "", "config `file` to use instead of the global one")
+	flags.StringVar(&opts.connection, "c", "", "connection `name` from the config, optional with a single connection")
+	flags.StringVar(&opts.connection, "connection", "", "same as -c")
+	flags.StringVar(&opts.sql, "e", "", "`SQL` to execute")
+	flags.StringVar(&opts.file, "f", "", "`file` of SQL statements to execute, - for stdin")
+	flags.StringVar(&opts.format, "format", "", "output `format`: table, csv, json or ndjson (default table on a terminal, csv otherwise); json puts several result sets in one outer array")
+	flags.BoolVar(&opts.yes, "yes", false, "run destructive statements on production connections without asking")
+
+	if err := flags.Parse(args); err != nil {
+		return opts, err
+	}
+
+	if flags.NArg() > 0 {
+		return opts, fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+
+	if opts.sql != "" && opts.file != "" {
+		return opts, errors.New("use either -e or -f, not both")
+	}
+
+	if opts.format == "" {
+		opts.format = "csv"
+		if terminal {
+			opts.format = "table"
+		}
+	}
+
+	if !validFormat(opts.format) {
+		return opts, fmt.Errorf("unknown format %q, use one of %s", opts.format, strings.Join(formats, ", "))
+	}
+
+	return opts, nil
+}
+
+func readSQL(opts execOptions, stdin io.Reader) (string, error) {
+	switch {
+	case opts.sql != "":
+		return opts.sql, nil
+	case opts.file != "" && opts.file != "-":
+		data, err := os.ReadFile(opts.file)
+		return string(data), err
+	}
+
+	if file, ok := stdin.(*os.File); ok && opts.file == "" && isatty.IsTerminal(file.Fd()) {
+		return "", errors.New("no SQL given, use -e, -f or pipe it in")
+	}
+
+	data, err := io.ReadAll(stdin)
+	return string(data), err
+}
+
+// findDatabase returns the named connection, or the only one when no name
+// is given.
+func findDatabase(cfg *config.AppConfig, name string) (*config.DatabaseConfig, error) {
+	names := make([]string, len(cfg.Databases))
+
+	for idx := range cfg.Databases {
+		names[idx] = cfg.Databases[idx].Name
+
+		if cfg.Databases[idx].Name == name {
+			return &cfg.Databases[idx], nil
+		}
+	}
+
+	if name == "" && len(cfg.Databases) == 1 {
+		return &cfg.Databases[0], nil
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("choose a connection with -c: %s", strings.Join(names, ", "))
+	}
+
+	return nil, fmt.Errorf("no connection named %q in the config, have %s", name, strings.Join(names, ", "))
+}
+
+// checkStatements applies the same read-only and production guards as the
+// TUI, where --yes stands in for the typed confirmation.
+func checkStatements(cfg *config.DatabaseConfig, statements []string, yes bool) error {
+	for _, statement := range statements {
+		if err := db.CheckReadOnly(cfg, statement); err != nil {
+			return err
+		}
+
+		if db.NeedsConfirmation(cfg, statement) && !yes {
+			return fmt.Errorf("%s is a production connection, pass --yes to run: %s", cfg.Name, statement)
+		}
+	}
+
+	return nil
+}
+
+// runExec runs `gosuite exec` and returns the process exit code.
+func runExec(configPath string, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	terminal := false
+	if file, ok := stdout.(*os.File); ok {
+		terminal = isatty.IsTerminal(file.Fd())
+	}
+
+	opts, err := parseExecArgs(args, stderr, terminal)
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+
+	if opts.config == "" {
+		opts.config = configPath
+	}
+
+	cfg, err := config.GetConfig(opts.config)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+
+	info, err := findDatabase(cfg, opts.connection)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+
+	sql, err := readSQL(opts, stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+
+	statements := db.Statements(sql)
+	if len(statements) == 0 {
+		fmt.Fprintln(stderr, "Error: no statements to execute")
+		return exitUsage
+	}
+
+	if err := checkStatements(info, statements, opts.yes); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitRefused
+	}
+
+	conn, err := db.Connect(info)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: connecting to %s: %v\n", info.Name, err)
+		return exitConnection
+	}
+	defer conn.Close()
+
+	// JSON output is one document, so the result sets are written together
+	// once every statement has run, or one has failed.
+	var sets []db.ExecuteResult
+
+	for idx, statement := range statements {
+		result, err := db.ExecuteSQL(conn, statement)
+		if err != nil {
+			writeJSONSets(stdout, sets)
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return exitSQLError
+		}
+
+		if opts.format == "json" {
+			sets = append(sets, result)
+			continue
+		}
+
+		if idx > 0 && opts.format != "ndjson" && len(result.Columns) > 0 {
+			fmt.Fprintln(stdout)
+		}
+
+		if err := writeResult(stdout, opts.format, result); err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return exitSQLError
+		}
+	}
+
+	if err := writeJSONSets(stdout, sets); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitSQLError
+	}
+
+	return exitOK
+}
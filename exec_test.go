@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	db "gosuite/db"
+	"gosuite/services/config"
+)
+
+func TestParseExecArgs(t *testing.T) {
+	opts, err := parseExecArgs([]string{"-c", "staging", "-e", "SELECT 1", "--format", "json"}, io.Discard, true)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if opts.connection != "staging" || opts.sql != "SELECT 1" || opts.format != "json" {
+		t.Errorf("Expected staging, SELECT 1 and json, got %+v", opts)
+	}
+
+	opts, _ = parseExecArgs([]string{"-f", "script.sql"}, io.Discard, true)
+	if opts.format != "table" {
+		t.Errorf("Expected table on a terminal, got %s", opts.format)
+	}
+
+	opts, _ = parseExecArgs(nil, io.Discard, false)
+	if opts.format != "csv" {
+		t.Errorf("Expected csv when piped, got %s", opts.format)
+	}
+
+	for _, args := range [][]string{
+		{"--format", "xml"},
+		{"-e", "SELECT 1", "-f", "script.sql"},
+		{"SELECT 1"},
+	} {
+		if _, err := parseExecArgs(args, io.Discard, true); err == nil {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+}
+
+func TestReadSQL(t *testing.T) {
+	sql, err := readSQL(execOptions{}, strings.NewReader("SELECT 1;\nSELECT 2;"))
+	if err != nil || sql != "SELECT 1;\nSELECT 2;" {
+		t.Errorf("Expected the SQL from stdin, got %q, %v", sql, err)
+	}
+
+	sql, _ = readSQL(execOptions{sql: "SELECT 3"}, strings.NewReader("SELECT 1"))
+	if sql != "SELECT 3" {
+		t.Errorf("Expected -e to win over stdin, got %q", sql)
+	}
+}
+
+func TestFindDatabase(t *testing.T) {
+	cfg := &config.AppConfig{Databases: []config.DatabaseConfig{{Name: "local"}, {Name: "prod"}}}
+
+	if info, err := findDatabase(cfg, "prod"); err != nil || info.Name != "prod" {
+		t.Errorf("Expected prod, got %v, %v", info, err)
+	}
+	if _, err := findDatabase(cfg, ""); err == nil {
+		t.Errorf("Expected an error when the connection is ambiguous")
+	}
+	if _, err := findDatabase(cfg, "staging"); err == nil {
+		t.Errorf("Expected an error for an unknown connection")
+	}
+
+	cfg.Databases = cfg.Databases[:1]
+	if info, err := findDatabase(cfg, ""); err != nil || info.Name != "local" {
+		t.Errorf("Expected the only connection, got %v, %v", info, err)
+	}
+}
+
+func TestCheckStatements(t *testing.T) {
+	prod := &config.DatabaseConfig{Name: "prod", Environment: "production"}
+	statements := []string{"SELECT 1", "DELETE FROM authors WHERE id = 1"}
+
+	if err := checkStatements(prod, statements, false); err == nil {
+		t.Errorf("Expected a DELETE on production to need --yes")
+	}
+	if err := checkStatements(prod, statements, true); err != nil {
+		t.Errorf("Expected --yes to allow it, got %v", err)
+	}
+
+	readOnly := &config.DatabaseConfig{Name: "replica", ReadOnly: true}
+	if err := checkStatements(readOnly, statements, true); !errors.Is(err, db.ErrReadOnly) {
+		t.Errorf("Expected %v, got %v", db.ErrReadOnly, err)
+	}
+}
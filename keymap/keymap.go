@@ -0,0 +1,451 @@
+package keymap
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+
+	config "gosuite/services/config"
+)
+
+func binding(help string, keys ...string) key.Binding {
+	return key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(strings.Join(keys, "/"), help),
+	)
+}
+
+type GlobalKeys struct {
+	Quit       key.Binding
+	NextTab    key.Binding
+	PrevTab    key.Binding
+	FocusQuery key.Binding
+	Explain    key.Binding
+	Help       key.Binding
+
+	WidenSidebar  key.Binding
+	NarrowSidebar key.Binding
+	GrowPane      key.Binding
+	ShrinkPane    key.Binding
+	ToggleSidebar key.Binding
+	Zoom          key.Binding
+	SaveLayout    key.Binding
+
+	Database  key.Binding
+	Tables    key.Binding
+	Query     key.Binding
+	Results   key.Binding
+	Plan      key.Binding
+	Processes key.Binding
+	Status    key.Binding
+}
+
+type DatabaseKeys struct {
+	ListKeys
+	Connect   key.Binding
+	Reconnect key.Binding
+	Add       key.Binding
+	Edit      key.Binding
+	Duplicate key.Binding
+	Delete    key.Binding
+	Import    key.Binding
+}
+
+// FormKeys work in the connection form, which also closes with
+// Dialog.Cancel.
+type FormKeys struct {
+	Next key.Binding
+	Prev key.Binding
+	Save key.Binding
+	Test key.Binding
+}
+
+type ListKeys struct {
+	Up   key.Binding
+	Down key.Binding
+}
+
+type TablesKeys struct {
+	ListKeys
+	Open     key.Binding
+	Describe key.Binding
+}
+
+type QueryKeys struct {
+	Execute key.Binding
+	Edit    key.Binding
+	Blur    key.Binding
+	Indent  key.Binding
+}
+
+type ResultKeys struct {
+	ListKeys
+	Left  key.Binding
+	Right key.Binding
+
+	Sort        key.Binding
+	Filter      key.Binding
+	QuickFilter key.Binding
+	NextPage    key.Binding
+	PrevPage    key.Binding
+
+	FollowReference key.Binding
+	ReferencedBy    key.Binding
+	Back            key.Binding
+
+	NextTab  key.Binding
+	PrevTab  key.Binding
+	PinTab   key.Binding
+	CloseTab key.Binding
+
+	MarkTab key.Binding
+	Diff    key.Binding
+	DiffKey key.Binding
+
+	Search       key.Binding
+	NextMatch    key.Binding
+	PrevMatch    key.Binding
+	ColumnFilter key.Binding
+	SortRows     key.Binding
+}
+
+type ProcessKeys struct {
+	ListKeys
+	Sort           key.Binding
+	Reverse        key.Binding
+	Filter         key.Binding
+	KillQuery      key.Binding
+	KillConnection key.Binding
+}
+
+type PickKeys struct {
+	Pick   key.Binding
+	Toggle key.Binding
+}
+
+type DialogKeys struct {
+	Accept  key.Binding
+	Decline key.Binding
+	Submit  key.Binding
+	Cancel  key.Binding
+}
+
+type KeyMap struct {
+	Global    GlobalKeys
+	Database  DatabaseKeys
+	Tables    TablesKeys
+	Query     QueryKeys
+	Result    ResultKeys
+	Explain   ListKeys
+	Processes ProcessKeys
+	Help      ListKeys
+	Pick      PickKeys
+	Dialog    DialogKeys
+	Form      FormKeys
+}
+
+func listKeys() ListKeys {
+	return ListKeys{
+		Up:   binding("Up", "up"),
+		Down: binding("Down", "down"),
+	}
+}
+
+func Default() KeyMap {
+	return KeyMap{
+		Global: GlobalKeys{
+			Quit:       binding("Quit", "q", "ctrl+c"),
+			NextTab:    binding("Next tab", "tab"),
+			PrevTab:    binding("Previous tab", "shift+tab"),
+			FocusQuery: binding("Focus on query", "/"),
+			Explain:    binding("Explain query", "ctrl+x"),
+			Help:       binding("Help", "?"),
+
+			WidenSidebar:  binding("Widen sidebar", ">", "ctrl+right"),
+			NarrowSidebar: binding("Narrow sidebar", "<", "ctrl+left"),
+			GrowPane:      binding("Grow pane", "+", "ctrl+down"),
+			ShrinkPane:    binding("Shrink pane", "-", "ctrl+up"),
+			ToggleSidebar: binding("Toggle sidebar", "Z"),
+			Zoom:          binding("Zoom pane", "z"),
+			SaveLayout:    binding("Save layout", "ctrl+s"),
+
+			Database:  binding("Database", "1"),
+			Tables:    binding("Tables", "2"),
+			Query:     binding("Query", "3"),
+			Results:   binding("Results", "4"),
+			Plan:      binding("Explain", "5"),
+			Processes: binding("Processes", "6"),
+			Status:    binding("Status", "7"),
+		},
+		Database: DatabaseKeys{
+			ListKeys:  listKeys(),
+			Connect:   binding("Connect", "enter"),
+			Reconnect: binding("Reconnect", "r"),
+			Add:       binding("Add connection", "a"),
+			Edit:      binding("Edit connection", "e"),
+			Duplicate: binding("Duplicate connection", "c"),
+			Delete:    binding("Delete connection", "x"),
+			Import:    binding("Import connections", "i"),
+		},
+		Tables: TablesKeys{
+			ListKeys: listKeys(),
+			Open:     binding("Browse table", "enter"),
+			Describe: binding("Describe table", "i"),
+		},
+		Query: QueryKeys{
+			Execute: binding("Execute", "enter"),
+			Edit:    binding("Edit query", "i"),
+			Blur:    binding("Stop editing", "esc"),
+			Indent:  binding("Indent", "tab"),
+		},
+		Result: ResultKeys{
+			ListKeys: listKeys(),
+			Left:     binding("Left", "left"),
+			Right:    binding("Right", "right"),
+
+			Sort:        binding("Sort by column", "s"),
+			Filter:      binding("Filter", "f"),
+			QuickFilter: binding("Filter by cell", "="),
+			NextPage:    binding("Next page", "pgdown"),
+			PrevPage:    binding("Previous page", "pgup"),
+
+			FollowReference: binding("Go to reference", "g"),
+			ReferencedBy:    binding("Referencing rows", "r"),
+			Back:            binding("Back", "b", "backspace"),
+
+			NextTab:  binding("Next result", "]"),
+			PrevTab:  binding("Previous result", "["),
+			PinTab:   binding("Pin result", "p"),
+			CloseTab: binding("Close result", "x"),
+
+			MarkTab: binding("Mark for diff", "m"),
+			Diff:    binding("Diff results", "d"),
+			DiffKey: binding("Change diff key", "c"),
+
+			Search:       binding("Search cells", "/"),
+			NextMatch:    binding("Next match", "n"),
+			PrevMatch:    binding("Previous match", "N"),
+			ColumnFilter: binding("Filter rows by column", "F"),
+			SortRows:     binding("Sort rows in place", "o"),
+		},
+		Explain: listKeys(),
+		Help:    listKeys(),
+		Processes: ProcessKeys{
+			ListKeys:       listKeys(),
+			Sort:           binding("Sort column", "s"),
+			Reverse:        binding("Reverse sort", "S"),
+			Filter:         binding("Filter", "f"),
+			KillQuery:      binding("Kill query", "x"),
+			KillConnection: binding("Kill connection", "X"),
+		},
+		Pick: PickKeys{
+			Pick:   binding("Pick", "enter"),
+			Toggle: key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "Select row")),
+		},
+		Dialog: DialogKeys{
+			Accept:  binding("Yes", "y", "Y"),
+			Decline: binding("No", "n", "N"),
+			Submit:  binding("Confirm", "enter"),
+			Cancel:  binding("Cancel", "esc", "ctrl+c"),
+		},
+		Form: FormKeys{
+			Next: binding("Next field", "tab", "down"),
+			Prev: binding("Previous field", "shift+tab", "up"),
+			Save: binding("Save", "ctrl+s"),
+			Test: binding("Test connection", "ctrl+t"),
+		},
+	}
+}
+
+// presets add keys on top of the defaults, so the arrow keys keep working.
+var presets = map[string]map[string][]string{
+	"default": {},
+	"vim": {
+		"database.up":      {"k"},
+		"database.down":    {"j"},
+		"tables.up":        {"k"},
+		"tables.down":      {"j"},
+		"result.up":        {"k"},
+		"result.down":      {"j"},
+		"result.left":      {"h"},
+		"result.right":     {"l"},
+		"result.next_page": {"ctrl+f"},
+		"result.prev_page": {"ctrl+b"},
+		"explain.up":       {"k"},
+		"explain.down":     {"j"},
+		"processes.up":     {"k"},
+		"processes.down":   {"j"},
+		"help.up":          {"k"},
+		"help.down":        {"j"},
+	},
+}
+
+// registry names every binding as "<pane>.<action>" for config overrides.
+func (k *KeyMap) registry() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"global.quit":        &k.Global.Quit,
+		"global.next_tab":    &k.Global.NextTab,
+		"global.prev_tab":    &k.Global.PrevTab,
+		"global.focus_query": &k.Global.FocusQuery,
+		"global.explain":     &k.Global.Explain,
+		"global.help":        &k.Global.Help,
+
+		"global.widen_sidebar":  &k.Global.WidenSidebar,
+		"global.narrow_sidebar": &k.Global.NarrowSidebar,
+		"global.grow_pane":      &k.Global.GrowPane,
+		"global.shrink_pane":    &k.Global.ShrinkPane,
+		"global.toggle_sidebar": &k.Global.ToggleSidebar,
+		"global.zoom":           &k.Global.Zoom,
+		"global.save_layout":    &k.Global.SaveLayout,
+
+		"global.database":  &k.Global.Database,
+		"global.tables":    &k.Global.Tables,
+		"global.query":     &k.Global.Query,
+		"global.results":   &k.Global.Results,
+		"global.plan":      &k.Global.Plan,
+		"global.processes": &k.Global.Processes,
+		"global.status":    &k.Global.Status,
+
+		"database.up":        &k.Database.Up,
+		"database.down":      &k.Database.Down,
+		"database.connect":   &k.Database.Connect,
+		"database.reconnect": &k.Database.Reconnect,
+		"database.add":       &k.Database.Add,
+		"database.edit":      &k.Database.Edit,
+		"database.duplicate": &k.Database.Duplicate,
+		"database.delete":    &k.Database.Delete,
+		"database.import":    &k.Database.Import,
+
+		"tables.up":       &k.Tables.Up,
+		"tables.down":     &k.Tables.Down,
+		"tables.open":     &k.Tables.Open,
+		"tables.describe": &k.Tables.Describe,
+
+		"query.execute": &k.Query.Execute,
+		"query.edit":    &k.Query.Edit,
+		"query.blur":    &k.Query.Blur,
+		"query.indent":  &k.Query.Indent,
+
+		"result.up":               &k.Result.Up,
+		"result.down":             &k.Result.Down,
+		"result.left":             &k.Result.Left,
+		"result.right":            &k.Result.Right,
+		"result.sort":             &k.Result.Sort,
+		"result.filter":           &k.Result.Filter,
+		"result.quick_filter":     &k.Result.QuickFilter,
+		"result.next_page":        &k.Result.NextPage,
+		"result.prev_page":        &k.Result.PrevPage,
+		"result.follow_reference": &k.Result.FollowReference,
+		"result.referenced_by":    &k.Result.ReferencedBy,
+		"result.back":             &k.Result.Back,
+		"result.next_tab":         &k.Result.NextTab,
+		"result.prev_tab":         &k.Result.PrevTab,
+		"result.pin_tab":          &k.Result.PinTab,
+		"result.close_tab":        &k.Result.CloseTab,
+		"result.mark_tab":         &k.Result.MarkTab,
+		"result.diff":             &k.Result.Diff,
+		"result.diff_key":         &k.Result.DiffKey,
+		"result.search":           &k.Result.Search,
+		"result.next_match":       &k.Result.NextMatch,
+		"result.prev_match":       &k.Result.PrevMatch,
+		"result.column_filter":    &k.Result.ColumnFilter,
+		"result.sort_rows":        &k.Result.SortRows,
+
+		"explain.up":   &k.Explain.Up,
+		"explain.down": &k.Explain.Down,
+
+		"help.up":   &k.Help.Up,
+		"help.down": &k.Help.Down,
+
+		"processes.up":              &k.Processes.Up,
+		"processes.down":            &k.Processes.Down,
+		"processes.sort":            &k.Processes.Sort,
+		"processes.reverse":         &k.Processes.Reverse,
+		"processes.filter":          &k.Processes.Filter,
+		"processes.kill_query":      &k.Processes.KillQuery,
+		"processes.kill_connection": &k.Processes.KillConnection,
+
+		"pick.pick":   &k.Pick.Pick,
+		"pick.toggle": &k.Pick.Toggle,
+
+		"dialog.accept":  &k.Dialog.Accept,
+		"dialog.decline": &k.Dialog.Decline,
+		"dialog.submit":  &k.Dialog.Submit,
+		"dialog.cancel":  &k.Dialog.Cancel,
+
+		"form.next": &k.Form.Next,
+		"form.prev": &k.Form.Prev,
+		"form.save": &k.Form.Save,
+		"form.test": &k.Form.Test,
+	}
+}
+
+// Actions lists the names that can be used in the keybindings config.
+func Actions() []string {
+	keys := Default()
+	actions := make([]string, 0)
+
+	for action := range keys.registry() {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	return actions
+}
+
+func setKeys(b *key.Binding, keys []string) {
+	b.SetKeys(keys...)
+	b.SetHelp(strings.Join(keys, "/"), b.Help().Desc)
+}
+
+// Load builds the keymap from the defaults, the chosen preset and the
+// per-action overrides, in that order.
+func Load(cfg config.KeybindingsConfig) (KeyMap, error) {
+	keys := Default()
+	registry := keys.registry()
+
+	presetName := cfg.Preset
+	if presetName == "" {
+		presetName = "default"
+	}
+
+	preset, ok := presets[presetName]
+	if !ok {
+		return keys, fmt.Errorf("unknown keybinding preset %q", cfg.Preset)
+	}
+
+	for action, extra := range preset {
+		b := registry[action]
+		setKeys(b, append(extra, b.Keys()...))
+	}
+
+	for action, override := range cfg.Bindings {
+		b, ok := registry[action]
+		if !ok {
+			return keys, fmt.Errorf("unknown keybinding action %q", action)
+		}
+		if len(override) == 0 {
+			return keys, fmt.Errorf("keybinding %q has no keys", action)
+		}
+		setKeys(b, override)
+	}
+
+	return keys, nil
+}
+
+// Keys is the active keymap used by every pane.
+var Keys = Default()
+
+func (k GlobalKeys) ShortHelp() []key.Binding {
+	return []key.Binding{k.Help, k.Quit, k.NextTab, k.PrevTab, k.FocusQuery}
+}
+
+func (k GlobalKeys) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Quit, k.Help, k.NextTab, k.PrevTab, k.FocusQuery, k.Explain},
+		{k.Database, k.Tables, k.Query, k.Results, k.Plan, k.Processes, k.Status},
+		{k.WidenSidebar, k.NarrowSidebar, k.GrowPane, k.ShrinkPane, k.ToggleSidebar, k.Zoom, k.SaveLayout},
+	}
+}
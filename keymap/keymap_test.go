@@ -0,0 +1,51 @@
+package keymap
+
+import (
+	"reflect"
+	"testing"
+
+	config "gosuite/services/config"
+)
+
+func TestLoadVimPreset(t *testing.T) {
+	keys, err := Load(config.KeybindingsConfig{Preset: "vim"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"k", "up"}
+	if got := keys.Result.Up.Keys(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if help := keys.Result.Up.Help().Key; help != "k/up" {
+		t.Errorf("Expected help key k/up, got %q", help)
+	}
+}
+
+func TestLoadOverrides(t *testing.T) {
+	keys, err := Load(config.KeybindingsConfig{
+		Bindings: map[string][]string{"result.sort": {"o"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := keys.Result.Sort.Keys(); !reflect.DeepEqual(got, []string{"o"}) {
+		t.Errorf("Expected [o], got %v", got)
+	}
+
+	if Default().Result.Sort.Keys()[0] != "s" {
+		t.Errorf("Expected overrides not to change the defaults")
+	}
+}
+
+func TestLoadRejectsUnknownActions(t *testing.T) {
+	if _, err := Load(config.KeybindingsConfig{Bindings: map[string][]string{"result.srot": {"o"}}}); err == nil {
+		t.Errorf("Expected an error for an unknown action")
+	}
+
+	if _, err := Load(config.KeybindingsConfig{Preset: "emacs"}); err == nil {
+		t.Errorf("Expected an error for an unknown preset")
+	}
+}
@@ -0,0 +1,263 @@
+package layout
+
+import (
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+
+	config "gosuite/services/config"
+)
+
+// Region is one of the four areas of the screen. The lower region shows
+// whichever of results, explain, processes or status was picked last.
+type Region int
+
+const (
+	Database Region = iota
+	Tables
+	Query
+	Lower
+)
+
+var Regions = []Region{Database, Tables, Query, Lower}
+
+// Sidebar reports whether the region is in the left column.
+func (r Region) Sidebar() bool {
+	return r == Database || r == Tables
+}
+
+type Rect struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+func (r Rect) Empty() bool {
+	return r.Width <= 0 || r.Height <= 0
+}
+
+func (r Rect) Contains(x int, y int) bool {
+	return !r.Empty() && x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
+// Areas holds the rectangle of every region, empty when it is hidden.
+type Areas [4]Rect
+
+const (
+	MinWidth  = 12
+	MinHeight = 3
+)
+
+// Layout sizes the panes in terminal cells. Sizes are clamped to the
+// screen when it is computed, so they survive the terminal shrinking.
+type Layout struct {
+	SidebarWidth   int
+	DatabaseHeight int
+	QueryHeight    int
+	Collapsed      bool
+	Zoomed         bool
+}
+
+func Default() Layout {
+	return Layout{
+		SidebarWidth:   28,
+		DatabaseHeight: 7,
+		QueryHeight:    12,
+	}
+}
+
+// FromConfig uses the saved sizes, falling back to the defaults for any
+// left unset.
+func FromConfig(cfg config.LayoutConfig) Layout {
+	l := Default()
+
+	if cfg.SidebarWidth > 0 {
+		l.SidebarWidth = cfg.SidebarWidth
+	}
+	if cfg.DatabaseHeight > 0 {
+		l.DatabaseHeight = cfg.DatabaseHeight
+	}
+	if cfg.QueryHeight > 0 {
+		l.QueryHeight = cfg.QueryHeight
+	}
+	l.Collapsed = cfg.SidebarCollapsed
+
+	return l
+}
+
+func (l Layout) Config() config.LayoutConfig {
+	return config.LayoutConfig{
+		SidebarWidth:     l.SidebarWidth,
+		DatabaseHeight:   l.DatabaseHeight,
+		QueryHeight:      l.QueryHeight,
+		SidebarCollapsed: l.Collapsed,
+	}
+}
+
+func clamp(value int, low int, high int) int {
+	if value < low {
+		value = low
+	}
+	if value > high {
+		value = high
+	}
+	return max(value, 0)
+}
+
+// Clamp keeps every split within a width × height screen.
+func (l Layout) Clamp(width int, height int) Layout {
+	l.SidebarWidth = clamp(l.SidebarWidth, MinWidth, width-MinWidth)
+	l.DatabaseHeight = clamp(l.DatabaseHeight, MinHeight, height-MinHeight)
+	l.QueryHeight = clamp(l.QueryHeight, MinHeight, height-MinHeight)
+	return l
+}
+
+// Resize moves the sidebar edge by dx and the split of the focused
+// region's column by dy, so a positive dy grows the focused pane.
+func (l Layout) Resize(focused Region, dx int, dy int) Layout {
+	l.SidebarWidth += dx
+
+	if focused == Tables || focused == Lower {
+		dy = -dy
+	}
+	if focused.Sidebar() {
+		l.DatabaseHeight += dy
+	} else {
+		l.QueryHeight += dy
+	}
+
+	return l
+}
+
+func (l Layout) ToggleCollapsed() Layout {
+	l.Collapsed = !l.Collapsed
+	return l
+}
+
+func (l Layout) ToggleZoom() Layout {
+	l.Zoomed = !l.Zoomed
+	return l
+}
+
+// ShowsSidebar reports whether the left column is drawn. A collapsed
+// sidebar still opens while one of its panes has focus.
+func (l Layout) ShowsSidebar(width int, focused Region) bool {
+	if l.Zoomed || width < 2*MinWidth {
+		return false
+	}
+	return !l.Collapsed || focused.Sidebar()
+}
+
+// Compute splits a width × height screen between the regions.
+func (l Layout) Compute(width int, height int, focused Region) Areas {
+	areas := Areas{}
+
+	if width <= 0 || height <= 0 {
+		return areas
+	}
+
+	if l.Zoomed {
+		areas[focused] = Rect{Width: width, Height: height}
+		return areas
+	}
+
+	l = l.Clamp(width, height)
+
+	sidebarWidth := 0
+	if l.ShowsSidebar(width, focused) {
+		sidebarWidth = l.SidebarWidth
+
+		areas[Database] = Rect{Width: sidebarWidth, Height: l.DatabaseHeight}
+		areas[Tables] = Rect{Y: l.DatabaseHeight, Width: sidebarWidth, Height: height - l.DatabaseHeight}
+	}
+
+	areas[Query] = Rect{X: sidebarWidth, Width: width - sidebarWidth, Height: l.QueryHeight}
+	areas[Lower] = Rect{X: sidebarWidth, Y: l.QueryHeight, Width: width - sidebarWidth, Height: height - l.QueryHeight}
+
+	return areas
+}
+
+// At returns the region under the cell at x, y.
+func (a Areas) At(x int, y int) (Region, bool) {
+	for _, region := range Regions {
+		if a[region].Contains(x, y) {
+			return region, true
+		}
+	}
+	return Database, false
+}
+
+// Render draws every visible region and puts them together column by
+// column.
+func (a Areas) Render(render func(region Region, rect Rect) string) string {
+	columns := map[int][]Region{}
+	xs := make([]int, 0)
+
+	for _, region := range Regions {
+		rect := a[region]
+		if rect.Empty() {
+			continue
+		}
+		if _, ok := columns[rect.X]; !ok {
+			xs = append(xs, rect.X)
+		}
+		columns[rect.X] = append(columns[rect.X], region)
+	}
+	sort.Ints(xs)
+
+	rendered := make([]string, 0, len(xs))
+	for _, x := range xs {
+		regions := columns[x]
+		sort.Slice(regions, func(i, j int) bool { return a[regions[i]].Y < a[regions[j]].Y })
+
+		panes := make([]string, len(regions))
+		for idx, region := range regions {
+			panes[idx] = render(region, a[region])
+		}
+		rendered = append(rendered, lipgloss.JoinVertical(lipgloss.Left, panes...))
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+}
+
+// Edge is a border between two panes that can be dragged.
+type Edge int
+
+const (
+	NoEdge Edge = iota
+	SidebarEdge
+	DatabaseEdge
+	QueryEdge
+)
+
+// EdgeAt returns the border under the pointer. Each border is two cells
+// wide, the bottom or right edge of one pane and the top or left of the
+// next.
+func (a Areas) EdgeAt(x int, y int) Edge {
+	database, tables, query, lower := a[Database], a[Tables], a[Query], a[Lower]
+
+	switch {
+	case !database.Empty() && !query.Empty() && (x == query.X-1 || x == query.X):
+		return SidebarEdge
+	case !database.Empty() && !tables.Empty() && x < database.Width && (y == tables.Y-1 || y == tables.Y):
+		return DatabaseEdge
+	case !query.Empty() && !lower.Empty() && x >= query.X && (y == lower.Y-1 || y == lower.Y):
+		return QueryEdge
+	}
+
+	return NoEdge
+}
+
+// Drag moves edge to follow the pointer.
+func (l Layout) Drag(edge Edge, x int, y int) Layout {
+	switch edge {
+	case SidebarEdge:
+		l.SidebarWidth = x + 1
+	case DatabaseEdge:
+		l.DatabaseHeight = y + 1
+	case QueryEdge:
+		l.QueryHeight = y + 1
+	}
+	return l
+}
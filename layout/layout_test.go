@@ -0,0 +1,126 @@
+package layout
+
+import (
+	"testing"
+
+	config "gosuite/services/config"
+)
+
+func TestCompute(t *testing.T) {
+	areas := Default().Compute(100, 40, Query)
+
+	expected := Areas{
+		Database: {X: 0, Y: 0, Width: 28, Height: 7},
+		Tables:   {X: 0, Y: 7, Width: 28, Height: 33},
+		Query:    {X: 28, Y: 0, Width: 72, Height: 12},
+		Lower:    {X: 28, Y: 12, Width: 72, Height: 28},
+	}
+
+	if areas != expected {
+		t.Errorf("Expected %v, got %v", expected, areas)
+	}
+}
+
+func TestComputeClampsToSmallScreens(t *testing.T) {
+	areas := Default().Compute(30, 8, Lower)
+
+	if areas[Database].Width != 30-MinWidth || areas[Query].Width != MinWidth {
+		t.Errorf("Expected the query pane to keep %d columns, got %v", MinWidth, areas)
+	}
+	if areas[Query].Height != 8-MinHeight || areas[Lower].Height != MinHeight {
+		t.Errorf("Expected the lower pane to keep %d rows, got %v", MinHeight, areas)
+	}
+
+	areas = Default().Compute(20, 8, Lower)
+
+	if !areas[Database].Empty() || areas[Query].Width != 20 {
+		t.Errorf("Expected no sidebar on a narrow screen, got %v", areas)
+	}
+}
+
+func TestCollapseAndZoom(t *testing.T) {
+	collapsed := Default().ToggleCollapsed()
+
+	if areas := collapsed.Compute(100, 40, Query); !areas[Tables].Empty() || areas[Query].X != 0 {
+		t.Errorf("Expected the sidebar to be hidden, got %v", areas)
+	}
+	if areas := collapsed.Compute(100, 40, Tables); areas[Tables].Empty() {
+		t.Errorf("Expected the sidebar to open while focused, got %v", areas)
+	}
+
+	areas := Default().ToggleZoom().Compute(100, 40, Lower)
+	expected := Areas{Lower: {Width: 100, Height: 40}}
+
+	if areas != expected {
+		t.Errorf("Expected %v, got %v", expected, areas)
+	}
+}
+
+func TestResize(t *testing.T) {
+	l := Default().Resize(Lower, 2, 3)
+
+	if l.SidebarWidth != 30 || l.QueryHeight != 9 {
+		t.Errorf("Expected width 30 and query height 9, got %v", l)
+	}
+
+	l = Default().Resize(Database, 0, 3)
+
+	if l.DatabaseHeight != 10 {
+		t.Errorf("Expected database height 10, got %v", l)
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	l := FromConfig(config.LayoutConfig{QueryHeight: 20, SidebarCollapsed: true})
+
+	if l.QueryHeight != 20 || l.SidebarWidth != Default().SidebarWidth || !l.Collapsed {
+		t.Errorf("Expected saved sizes over the defaults, got %v", l)
+	}
+}
+
+func TestAt(t *testing.T) {
+	areas := Default().Compute(100, 40, Query)
+
+	if region, ok := areas.At(50, 20); !ok || region != Lower {
+		t.Errorf("Expected %v, got %v", Lower, region)
+	}
+	if _, ok := areas.At(100, 20); ok {
+		t.Errorf("Expected nothing outside the screen")
+	}
+}
+
+func TestEdgeAt(t *testing.T) {
+	areas := Default().Compute(100, 40, Query)
+
+	tests := []struct {
+		x, y     int
+		expected Edge
+	}{
+		{27, 20, SidebarEdge},
+		{28, 3, SidebarEdge},
+		{10, 6, DatabaseEdge},
+		{10, 7, DatabaseEdge},
+		{60, 11, QueryEdge},
+		{60, 12, QueryEdge},
+		{60, 20, NoEdge},
+		{10, 20, NoEdge},
+	}
+
+	for _, test := range tests {
+		if got := areas.EdgeAt(test.x, test.y); got != test.expected {
+			t.Errorf("Expected %v at %d,%d, got %v", test.expected, test.x, test.y, got)
+		}
+	}
+
+	if got := Default().ToggleZoom().Compute(100, 40, Query).EdgeAt(27, 20); got != NoEdge {
+		t.Errorf("Expected no edges while zoomed, got %v", got)
+	}
+}
+
+func TestDrag(t *testing.T) {
+	l := Default().Drag(SidebarEdge, 39, 0).Drag(QueryEdge, 0, 19)
+
+	if l.SidebarWidth != 40 || l.QueryHeight != 20 {
+		t.Errorf("Expected width 40 and query height 20, got %v", l)
+	}
+}
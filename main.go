@@ -1,6 +1,8 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"os"
 
@@ -11,11 +13,20 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	db "gosuite/db"
+	design "gosuite/design"
+	keymap "gosuite/keymap"
+	layout "gosuite/layout"
 	query "gosuite/query"
 	result "gosuite/result"
 	"gosuite/services/config"
 	tables "gosuite/tables"
+	theme "gosuite/theme"
+	confirm "gosuite/views/confirm"
 	database "gosuite/views/database"
+	explain "gosuite/views/explain"
+	keyhelp "gosuite/views/keyhelp"
+	processlist "gosuite/views/processlist"
+	status "gosuite/views/status"
 )
 
 const (
@@ -23,6 +34,9 @@ const (
 	TablesTab
 	QueryTab
 	ResultTab
+	ExplainTab
+	ProcessTab
+	StatusTab
 )
 
 type MainModel struct {
@@ -34,63 +48,198 @@ type MainModel struct {
 	terminalWidth  int
 	terminalHeight int
 	selectedTab    int
+	mode           Mode
+	layout         layout.Layout
+	drag           layout.Edge
+	notice         string
+	configStamps   configStamps
+	// lowerTab is the pane shown below the query editor.
+	lowerTab int
 
 	databaseModel database.Model
 	tablesModel   tables.Model
 	resultModel   result.Model
 	queryModel    query.Model
+	explainModel  explain.Model
+	processModel  processlist.Model
+	statusModel   status.Model
+
+	confirmModel confirm.Model
+	showHelp     bool
+	helpOffset   int
 
-	// Keys
-	keys keyMap
 	help help.Model
 }
 
-type keyMap map[string]key.Binding
+// keyMap is the help for the focused pane's keys followed by the global
+// keys.
+type keyMap struct {
+	pane   help.KeyMap
+	global keymap.GlobalKeys
+}
 
-var QuitKey = key.NewBinding(
-	key.WithKeys("q", "ctrl+c"),
-	key.WithHelp("q", "Quit"),
-)
+func (k keyMap) ShortHelp() []key.Binding {
+	return append(append([]key.Binding{}, k.pane.ShortHelp()...), k.global.ShortHelp()...)
+}
 
-var TabKey = key.NewBinding(
-	key.WithKeys("tab"),
-	key.WithHelp("tab", "Next tab"),
-)
+func (k keyMap) FullHelp() [][]key.Binding {
+	return append(append([][]key.Binding{}, k.pane.FullHelp()...), k.global.FullHelp()...)
+}
 
-var ShiftTabKey = key.NewBinding(
-	key.WithKeys("shift+tab"),
-	key.WithHelp("shift+tab", "Previous tab"),
-)
+func (m MainModel) paneKeys(tab int) help.KeyMap {
+	switch tab {
+	case DatabaseTab:
+		return m.databaseModel
+	case TablesTab:
+		return m.tablesModel
+	case QueryTab:
+		return m.queryModel
+	case ResultTab:
+		return m.resultModel
+	case ExplainTab:
+		return m.explainModel
+	case ProcessTab:
+		return m.processModel
+	default:
+		return m.statusModel
+	}
+}
 
-var FocusQueryKey = key.NewBinding(
-	key.WithKeys("/"),
-	key.WithHelp("/", "Focus on query"),
-)
+func (m MainModel) helpKeys() keyMap {
+	return keyMap{pane: m.paneKeys(m.selectedTab), global: keymap.Keys.Global}
+}
 
-func (k keyMap) ShortHelp() []key.Binding {
-	return []key.Binding{
-		QuitKey,
-		TabKey,
-		ShiftTabKey,
-		FocusQueryKey,
+func (m MainModel) helpSections() []keyhelp.Section {
+	return []keyhelp.Section{
+		{Title: "Global", Keys: keymap.Keys.Global},
+		{Title: "[1] Database", Keys: m.databaseModel},
+		{Title: "[2] Tables", Keys: m.tablesModel},
+		{Title: "[3] Query", Keys: m.queryModel},
+		{Title: "[4] Results", Keys: m.resultModel},
+		{Title: "[5] Explain", Keys: m.explainModel},
+		{Title: "[6] Processes", Keys: m.processModel},
+		{Title: "[7] Status", Keys: m.statusModel},
 	}
 }
 
-func (k keyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{
-		{
-			QuitKey,
-			TabKey,
-			ShiftTabKey,
-			FocusQueryKey,
-		},
+// tabForKey returns the tab selected by one of the number keys.
+func tabForKey(msg tea.KeyMsg) (int, bool) {
+	keys := keymap.Keys.Global
+
+	tabs := []key.Binding{
+		DatabaseTab: keys.Database,
+		TablesTab:   keys.Tables,
+		QueryTab:    keys.Query,
+		ResultTab:   keys.Results,
+		ExplainTab:  keys.Plan,
+		ProcessTab:  keys.Processes,
+		StatusTab:   keys.Status,
+	}
+
+	for tab, binding := range tabs {
+		if key.Matches(msg, binding) {
+			return tab, true
+		}
 	}
+
+	return 0, false
 }
 
 type errMsg error
 
+type estimateMsg struct {
+	query string
+	rows  int64
+	err   error
+}
+
 func (m MainModel) Init() tea.Cmd {
-	return textarea.Blink
+	cmds := []tea.Cmd{textarea.Blink}
+
+	if m.connection != nil && m.connection.GetConfig() != nil {
+		cmds = append(cmds, db.ConnectCmd(m.connection.GetConfig()))
+	}
+
+	cmds = append(cmds, watchConfig(m.config), database.DiscoverCmd())
+
+	return tea.Batch(cmds...)
+}
+
+// execute runs query on the active connection once it has passed the
+// read-only and production checks, asking for confirmation if needed.
+func (m MainModel) execute(query string) (MainModel, tea.Cmd) {
+	conn := m.connection.GetConnection()
+	cfg := m.connection.GetConfig()
+
+	if conn == nil {
+		return m, func() tea.Msg {
+			return db.ExecuteError{Query: query, Err: fmt.Errorf("not connected")}
+		}
+	}
+
+	if err := db.CheckReadOnly(cfg, query); err != nil {
+		return m, func() tea.Msg {
+			return db.ExecuteError{Query: query, Err: err}
+		}
+	}
+
+	if !db.NeedsConfirmation(cfg, query) {
+		return m, db.ExucuteSQLCmd(query, conn)
+	}
+
+	if db.IsUnfiltered(query) {
+		return m, func() tea.Msg {
+			rows, err := db.EstimateRows(conn, query)
+			return estimateMsg{query: query, rows: rows, err: err}
+		}
+	}
+
+	m.confirmModel = m.confirmDestructive(query, nil)
+
+	return m, nil
+}
+
+func (m MainModel) explain() tea.Cmd {
+	conn := m.connection.GetConnection()
+	statement := m.queryModel.CurrentStatement()
+
+	if conn == nil || statement == "" {
+		return nil
+	}
+
+	return db.ExplainCmd(conn, statement)
+}
+
+func (m MainModel) confirmDestructive(query string, details []string) confirm.Model {
+	cfg := m.connection.GetConfig()
+
+	expect := cfg.Database
+	if expect == "" {
+		expect = cfg.Name
+	}
+
+	details = append([]string{
+		fmt.Sprintf("%s is a production database.", cfg.Name),
+		"",
+		query,
+	}, details...)
+
+	return confirm.New(
+		"Run destructive statement?",
+		details,
+		expect,
+		db.ExucuteSQLCmd(query, m.connection.GetConnection()),
+	)
+}
+
+// stale is true for the result of connecting to something other than the
+// current connection, such as a reconnect after switching away. A pending
+// connection starts a switch, so it's never stale.
+func (m MainModel) stale(conn db.Connection) bool {
+	if _, ok := conn.(db.ConnectionPending); ok {
+		return false
+	}
+	return m.connection == nil || conn.GetConfig() != m.connection.GetConfig()
 }
 
 func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -105,48 +254,207 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case query.FocusOnQueryMsg:
 		m.selectedTab = QueryTab
 
+	case db.Connection:
+		if m.stale(msg) {
+			if conn := msg.GetConnection(); conn != nil {
+				conn.Close()
+			}
+			return m, nil
+		}
+
+		m.connection = msg
+		design.SetProduction(msg.GetConfig() != nil && msg.GetConfig().IsProduction())
+
+		switch conn := msg.(type) {
+		case db.ConnectionSuccess:
+			cmds = append(cmds, db.PingCmd(conn.GetConnection(), conn.GetConfig(), 0))
+		case db.ConnectionLost:
+			cmds = append(cmds, db.ReconnectCmd(conn))
+		}
+
+	case db.PingMsg:
+		if msg.DB == nil || msg.DB != m.connection.GetConnection() {
+			break
+		}
+
+		if msg.Err == nil || errors.Is(msg.Err, db.ErrPoolBusy) {
+			cmds = append(cmds, db.PingCmd(msg.DB, m.connection.GetConfig(), db.PingInterval))
+			break
+		}
+
+		lost := db.Lost(m.connection, msg.Err)
+		cmds = append(cmds, func() tea.Msg { return lost })
+
+	case db.ExecuteRequestMsg:
+		m, cmd = m.execute(msg.Query)
+		cmds = append(cmds, cmd)
+
+	case result.OpenTableMsg:
+		m.selectedTab = ResultTab
+
+	case db.ExplainResult:
+		m.selectedTab = ExplainTab
+
+	case processlist.KillRequestMsg:
+		statement := db.KillStatement(msg.Process.ID, msg.Connection)
+
+		if err := db.CheckReadOnly(m.connection.GetConfig(), statement); err != nil {
+			cmds = append(cmds, func() tea.Msg {
+				return db.KillResult{ID: msg.Process.ID, Connection: msg.Connection, Err: err}
+			})
+			break
+		}
+
+		if conn := m.connection.GetConnection(); conn != nil {
+			m.confirmModel = confirm.New(
+				statement+"?",
+				[]string{
+					fmt.Sprintf("%s@%s on %s, running for %ds", msg.Process.User, msg.Process.Host, msg.Process.DB, msg.Process.Time),
+					msg.Process.Info,
+				},
+				"",
+				db.KillCmd(conn, msg.Process.ID, msg.Connection),
+			)
+		}
+
+	case database.DeleteRequestMsg:
+		m.confirmModel = confirm.New(
+			fmt.Sprintf("Delete the %s connection?", msg.Name),
+			[]string{"It is removed from " + msg.Path + "."},
+			"",
+			database.DeleteCmd(msg.Path, msg.Name),
+		)
+
+	case database.SavedMsg:
+		// Reload now rather than on the next poll, the reload sums up the
+		// change.
+		if msg.Err == nil {
+			m.configStamps = statConfig(m.config.Files)
+			cmds = append(cmds, reloadConfig(m.config, false))
+		}
+
+	case estimateMsg:
+		estimate := fmt.Sprintf("This has no WHERE clause and will affect an estimated %d rows.", msg.rows)
+		if msg.err != nil {
+			estimate = fmt.Sprintf("This has no WHERE clause, the affected rows could not be estimated: %v", msg.err)
+		}
+		m.confirmModel = m.confirmDestructive(msg.query, []string{"", estimate})
+
+	case tea.MouseMsg:
+		return m.updateMouse(msg)
+
+	case configPollMsg:
+		m, cmd = m.poll(msg)
+		cmds = append(cmds, cmd)
+
+	case configReloadedMsg:
+		m, cmd = m.reload(msg)
+		cmds = append(cmds, cmd)
+
+	case layoutSavedMsg:
+		m.notice = "Layout saved to " + m.config.Path
+		// Saving isn't an edit to reload.
+		m.configStamps = statConfig(m.config.Files)
+		if msg.err != nil {
+			m.notice = theme.Style(theme.Current.Error).Render("Could not save the layout: " + msg.err.Error())
+		}
+
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "shift+tab":
+		m.notice = ""
+
+		if m.confirmModel.Active() {
+			m.confirmModel, cmd = m.confirmModel.Update(msg)
+			return m, cmd
+		}
+
+		if m.databaseModel.Editing() {
+			m.databaseModel, cmd = m.databaseModel.Update(msg, true)
+			m.mode = m.inputMode()
+			return m, cmd
+		}
+
+		keys := keymap.Keys.Global
+
+		if m.showHelp {
+			switch {
+			case key.Matches(msg, keys.Help, keymap.Keys.Dialog.Cancel):
+				m.showHelp = false
+			case key.Matches(msg, keymap.Keys.Help.Up):
+				m.helpOffset = max(m.helpOffset-1, 0)
+			case key.Matches(msg, keymap.Keys.Help.Down):
+				m.helpOffset = min(m.helpOffset+1, keyhelp.MaxOffset(m.helpSections(), m.terminalWidth, m.terminalHeight))
+			case key.Matches(msg, keys.Quit):
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		// Only keys that can't be typed work globally while editing.
+		if m.mode == InsertMode {
+			switch {
+			case msg.Type == tea.KeyCtrlC:
+				return m, tea.Quit
+			case key.Matches(msg, keys.Explain):
+				cmds = append(cmds, m.explain())
+			}
+			break
+		}
+
+		switch {
+		case key.Matches(msg, keys.Help):
+			m.showHelp = true
+			m.helpOffset = 0
+			return m, nil
+
+		case key.Matches(msg, keys.PrevTab):
 			m.selectedTab--
 
 			if m.selectedTab < DatabaseTab {
-				m.selectedTab = ResultTab
+				m.selectedTab = StatusTab
 			}
 
-		case "tab":
+		case key.Matches(msg, keys.NextTab):
 
 			m.selectedTab++
 
-			if m.selectedTab > ResultTab {
+			if m.selectedTab > StatusTab {
 				m.selectedTab = DatabaseTab
 			}
 
-		case "1":
-			if !m.queryModel.Input.Focused() {
-				m.selectedTab = DatabaseTab
-			}
-		case "2":
-			if !m.queryModel.Input.Focused() {
-				m.selectedTab = TablesTab
-			}
-		case "3":
-			if !m.queryModel.Input.Focused() {
-				m.selectedTab = QueryTab
-			}
-		case "4":
-			if !m.queryModel.Input.Focused() {
-				m.selectedTab = ResultTab
-			}
+		case key.Matches(msg, keys.Explain):
+			cmds = append(cmds, m.explain())
 
-		case "/":
+		// The Results pane searches its cells with the same key.
+		case key.Matches(msg, keys.FocusQuery) &&
+			!(m.selectedTab == ResultTab && key.Matches(msg, keymap.Keys.Result.Search)):
 			cmd = query.FocusOnQuery()
 			cmds = append(cmds, cmd)
 
-		case "ctrl+c":
-			return m, tea.Quit
-		case "q":
+		case key.Matches(msg, keys.Quit):
 			return m, tea.Quit
+
+		case key.Matches(msg, keys.WidenSidebar):
+			m.resize(1, 0)
+		case key.Matches(msg, keys.NarrowSidebar):
+			m.resize(-1, 0)
+		case key.Matches(msg, keys.GrowPane):
+			m.resize(0, 1)
+		case key.Matches(msg, keys.ShrinkPane):
+			m.resize(0, -1)
+
+		case key.Matches(msg, keys.ToggleSidebar):
+			m.layout = m.layout.ToggleCollapsed()
+
+		case key.Matches(msg, keys.Zoom):
+			m.layout = m.layout.ToggleZoom()
+
+		case key.Matches(msg, keys.SaveLayout):
+			cmds = append(cmds, saveLayout(m.config.Path, m.layout))
+
+		default:
+			if tab, ok := tabForKey(msg); ok {
+				m.selectedTab = tab
+			}
 		}
 	}
 
@@ -156,91 +464,209 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	m.tablesModel, cmd = m.tablesModel.Update(msg, m.selectedTab == TablesTab, &m.connection)
 	cmds = append(cmds, cmd)
 
-	m.resultModel, cmd = m.resultModel.Update(msg, m.selectedTab == ResultTab)
+	m.resultModel, cmd = m.resultModel.Update(msg, m.selectedTab == ResultTab, &m.connection)
 	cmds = append(cmds, cmd)
 
 	m.queryModel, cmd = m.queryModel.Update(msg, m.selectedTab == QueryTab, &m.connection)
 	cmds = append(cmds, cmd)
 
+	m.explainModel, cmd = m.explainModel.Update(msg, m.selectedTab == ExplainTab)
+	cmds = append(cmds, cmd)
+
+	m.processModel, cmd = m.processModel.Update(msg, m.selectedTab == ProcessTab, &m.connection)
+	cmds = append(cmds, cmd)
+
+	m.statusModel, cmd = m.statusModel.Update(msg, m.selectedTab == StatusTab, &m.connection)
+	cmds = append(cmds, cmd)
+
+	if m.selectedTab >= ResultTab {
+		m.lowerTab = m.selectedTab
+	}
+
+	m.processModel, cmd = m.processModel.SetVisible(m.lowerTab == ProcessTab)
+	cmds = append(cmds, cmd)
+
+	m.statusModel, cmd = m.statusModel.SetVisible(m.lowerTab == StatusTab)
+	cmds = append(cmds, cmd)
+
+	m.mode = m.inputMode()
+
 	return m, tea.Batch(cmds...)
 }
 
 func (m MainModel) View() string {
-	safeWidth := m.terminalWidth - 5
-	safeHeight := m.terminalHeight - 6
+	if m.confirmModel.Active() {
+		return m.confirmModel.View(m.terminalWidth, m.terminalHeight)
+	}
 
-	leftColWidth := 25
-	rightColWidth := safeWidth - leftColWidth
+	if m.databaseModel.Editing() {
+		return m.databaseModel.EditorView(m.terminalWidth, m.terminalHeight)
+	}
 
-	databaseHeight := 5
-	tablesHeight := safeHeight - databaseHeight
+	if m.showHelp {
+		return keyhelp.View(m.helpSections(), keymap.Keys.Global.Help, keymap.Keys.Help, m.terminalWidth, m.terminalHeight, m.helpOffset)
+	}
 
-	queryHeight := 10
-	resultHeight := safeHeight - queryHeight
+	statusLine := lipgloss.JoinHorizontal(lipgloss.Top, m.mode.View(), " ", m.help.View(m.helpKeys()))
+	if m.notice != "" {
+		statusLine = lipgloss.JoinHorizontal(lipgloss.Top, m.mode.View(), " ", m.notice)
+	}
 
-	databaseTab := m.databaseModel.View(m.selectedTab == DatabaseTab, leftColWidth, databaseHeight)
-	tablesTab := m.tablesModel.View(m.selectedTab == TablesTab, leftColWidth, tablesHeight)
-	queryTab := m.queryModel.View(m.selectedTab == QueryTab, rightColWidth, queryHeight)
-	resultTab := m.resultModel.View(m.selectedTab == ResultTab, rightColWidth, resultHeight)
+	panes := m.areas().Render(m.renderRegion)
 
-	leftCol := lipgloss.JoinVertical(lipgloss.Left, databaseTab, tablesTab)
-	rightCol := lipgloss.JoinVertical(lipgloss.Left, queryTab, resultTab)
+	return lipgloss.JoinVertical(lipgloss.Top, panes, lipgloss.NewStyle().MaxWidth(m.terminalWidth).Render(statusLine))
+}
 
-	layout := lipgloss.JoinVertical(
-		lipgloss.Top,
-		lipgloss.JoinHorizontal(lipgloss.Top, leftCol, rightCol),
-		m.help.View(m.keys),
-	)
+func (m MainModel) renderRegion(region layout.Region, rect layout.Rect) string {
+	switch region {
+	case layout.Database:
+		return m.databaseModel.View(m.selectedTab == DatabaseTab, rect.Width, rect.Height)
+	case layout.Tables:
+		return m.tablesModel.View(m.selectedTab == TablesTab, rect.Width, rect.Height)
+	case layout.Query:
+		return m.queryModel.View(m.selectedTab == QueryTab, rect.Width, rect.Height)
+	}
 
-	return layout
+	selected := m.selectedTab == m.lowerTab
+
+	switch m.lowerTab {
+	case ExplainTab:
+		return m.explainModel.View(selected, rect.Width, rect.Height)
+	case ProcessTab:
+		return m.processModel.View(selected, rect.Width, rect.Height)
+	case StatusTab:
+		return m.statusModel.View(selected, rect.Width, rect.Height)
+	default:
+		return m.resultModel.View(selected, rect.Width, rect.Height)
+	}
 }
 
-func main() {
-	config, err := config.GetConfig()
+// focusedRegion is the part of the screen the selected tab is shown in.
+func (m MainModel) focusedRegion() layout.Region {
+	switch m.selectedTab {
+	case DatabaseTab:
+		return layout.Database
+	case TablesTab:
+		return layout.Tables
+	case QueryTab:
+		return layout.Query
+	default:
+		return layout.Lower
+	}
+}
+
+// areas lays the panes out above the status line.
+func (m MainModel) areas() layout.Areas {
+	return m.layout.Compute(m.terminalWidth, m.terminalHeight-1, m.focusedRegion())
+}
+
+func (m *MainModel) resize(dx int, dy int) {
+	m.layout = m.layout.Resize(m.focusedRegion(), dx, dy).Clamp(m.terminalWidth, m.terminalHeight-1)
+}
+
+type layoutSavedMsg struct {
+	err error
+}
+
+func saveLayout(path string, l layout.Layout) tea.Cmd {
+	return func() tea.Msg {
+		if path == "" {
+			return layoutSavedMsg{err: fmt.Errorf("no config file to save the layout to")}
+		}
+		return layoutSavedMsg{err: config.SaveLayout(path, l.Config())}
+	}
+}
+
+func newHelp() help.Model {
+	model := help.New()
+
+	keyStyle := theme.Style(theme.Current.Text)
+	mutedStyle := theme.Style(theme.Current.Muted)
+
+	model.Styles.ShortKey = keyStyle
+	model.Styles.FullKey = keyStyle
+	model.Styles.ShortDesc = mutedStyle
+	model.Styles.FullDesc = mutedStyle
+	model.Styles.ShortSeparator = mutedStyle
+	model.Styles.FullSeparator = mutedStyle
+	model.Styles.Ellipsis = mutedStyle
+
+	return model
+}
+
+func initialModel(cfg *config.AppConfig) MainModel {
+	var conn db.Connection = db.ConnectionPending{}
+
+	if len(cfg.Databases) > 0 {
+		conn = db.ConnectionPending{
+			Config: &cfg.Databases[0],
+		}
+	}
 
+	return MainModel{
+		config:        cfg,
+		connection:    conn,
+		err:           nil,
+		selectedTab:   TablesTab,
+		lowerTab:      ResultTab,
+		databaseModel: database.InitModel(cfg),
+		tablesModel:   tables.InitModel(),
+		resultModel:   result.InitModel(),
+		queryModel:    query.InitModel(),
+		explainModel:  explain.InitModel(),
+		processModel:  processlist.InitModel(cfg.ProcessList.Interval),
+		statusModel:   status.InitModel(cfg.Dashboard.Interval),
+		help:          newHelp(),
+		layout:        layout.FromConfig(cfg.Layout),
+		configStamps:  statConfig(cfg.Files),
+	}
+}
+
+// loadKeysAndTheme sets the keymap and palette every pane draws with.
+func loadKeysAndTheme(cfg *config.AppConfig) error {
+	var err error
+
+	keymap.Keys, err = keymap.Load(cfg.Keybindings)
 	if err != nil {
-		fmt.Printf("Error: %v", err)
-		os.Exit(1)
+		return err
 	}
 
-	databasesLength := len(config.Databases)
+	theme.Current, err = theme.Load(cfg.Theme)
+	return err
+}
 
-	if databasesLength == 0 {
-		fmt.Printf("No databases found in config file, please add at least one database.")
+func main() {
+	configPath := flag.String("config", "", "config `file` to use instead of $XDG_CONFIG_HOME/gosuite/config.yml")
+	flag.Parse()
+
+	switch flag.Arg(0) {
+	case "exec":
+		os.Exit(runExec(*configPath, flag.Args()[1:], os.Stdin, os.Stdout, os.Stderr))
+	case "pick":
+		os.Exit(runPick(*configPath, flag.Args()[1:], os.Stdout, os.Stderr))
+	case "config":
+		os.Exit(runConfig(*configPath, flag.Args()[1:], os.Stdout, os.Stderr))
+	}
+
+	config, err := config.GetConfig(*configPath)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	firstConfig := config.Databases[0]
+	err = loadKeysAndTheme(config)
 
-	conn := db.ConnectionPending{
-		Config: &firstConfig,
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 
-	databaseModel := database.InitModel()
-	tablesModel := tables.InitModel()
-	resultModel := result.InitModel()
-	queryModel := query.InitModel()
+	model := initialModel(config)
 
-	model := MainModel{
-		connection:    conn,
-		err:           nil,
-		selectedTab:   TablesTab,
-		databaseModel: databaseModel,
-		tablesModel:   tablesModel,
-		resultModel:   resultModel,
-		queryModel:    queryModel,
-		keys: keyMap{
-			"Quit":       QuitKey,
-			"Tab":        TabKey,
-			"ShiftTab":   ShiftTabKey,
-			"FocusQuery": FocusQueryKey,
-		},
-		help: help.NewModel(),
-	}
-
-	p := tea.NewProgram(model, tea.WithAltScreen())
+	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	if err := p.Start(); err != nil {
-		fmt.Printf("Error: %v", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
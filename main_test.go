@@ -1,13 +1,28 @@
 package main
 
 import (
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"gosuite/db"
+	"gosuite/query"
+	"gosuite/result"
+	"gosuite/services/config"
+	"gosuite/services/importer"
+	"gosuite/views/database"
+	"gosuite/views/keyhelp"
 )
 
 func TestView(t *testing.T) {
-	m := initialModel()
+	m := initialModel(&config.AppConfig{})
 
 	res := m.View()
 
@@ -22,3 +37,482 @@ func TestBorderStyling(t *testing.T) {
 
 	println(res)
 }
+
+func runes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func update(m MainModel, msg tea.Msg) (MainModel, tea.Cmd) {
+	model, cmd := m.Update(msg)
+	return model.(MainModel), cmd
+}
+
+func quits(cmd tea.Cmd) bool {
+	if cmd == nil {
+		return false
+	}
+	_, ok := cmd().(tea.QuitMsg)
+	return ok
+}
+
+// find runs cmd and the commands it batches, without waiting on the ones
+// that talk to a database, and returns the first message of type T.
+func find[T tea.Msg](cmd tea.Cmd) (T, bool) {
+	var zero T
+	if cmd == nil {
+		return zero, false
+	}
+
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+
+	select {
+	case msg := <-done:
+		if found, ok := msg.(T); ok {
+			return found, true
+		}
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for _, c := range batch {
+				if found, ok := find[T](c); ok {
+					return found, true
+				}
+			}
+		}
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	return zero, false
+}
+
+func editing(t *testing.T) MainModel {
+	m, _ := update(initialModel(&config.AppConfig{}), query.FocusOnQueryMsg{})
+
+	if m.mode != InsertMode {
+		t.Fatalf("Expected %v, got %v", InsertMode, m.mode)
+	}
+
+	return m
+}
+
+func TestQuitInNormalMode(t *testing.T) {
+	m := initialModel(&config.AppConfig{})
+
+	if m.mode != NormalMode {
+		t.Errorf("Expected %v, got %v", NormalMode, m.mode)
+	}
+
+	_, cmd := update(m, runes("q"))
+
+	if !quits(cmd) {
+		t.Errorf("Expected q to quit in normal mode")
+	}
+}
+
+func TestTypingInInsertMode(t *testing.T) {
+	m := editing(t)
+
+	for _, key := range []string{"s", "e", "l", "q", "2", "?", "/"} {
+		var cmd tea.Cmd
+		m, cmd = update(m, runes(key))
+
+		if quits(cmd) {
+			t.Fatalf("Expected %q not to quit while typing", key)
+		}
+	}
+
+	if m.queryModel.Input.Value() != "selq2?/" {
+		t.Errorf("Expected %q, got %q", "selq2?/", m.queryModel.Input.Value())
+	}
+	if m.selectedTab != QueryTab {
+		t.Errorf("Expected %v, got %v", QueryTab, m.selectedTab)
+	}
+	if m.showHelp {
+		t.Errorf("Expected help to stay closed while typing")
+	}
+}
+
+func TestTabIndentsInInsertMode(t *testing.T) {
+	m := editing(t)
+
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyTab})
+
+	if m.selectedTab != QueryTab {
+		t.Errorf("Expected %v, got %v", QueryTab, m.selectedTab)
+	}
+	if m.queryModel.Input.Value() != "  " {
+		t.Errorf("Expected %q, got %q", "  ", m.queryModel.Input.Value())
+	}
+}
+
+func TestEscapeReturnsToNormalMode(t *testing.T) {
+	m := editing(t)
+
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+
+	if m.mode != NormalMode {
+		t.Errorf("Expected %v, got %v", NormalMode, m.mode)
+	}
+
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyTab})
+
+	if m.selectedTab != ResultTab {
+		t.Errorf("Expected %v, got %v", ResultTab, m.selectedTab)
+	}
+
+	m, _ = update(m, runes("3"))
+	m, _ = update(m, runes("i"))
+
+	if m.mode != InsertMode {
+		t.Errorf("Expected %v, got %v", InsertMode, m.mode)
+	}
+}
+
+func TestCtrlCQuitsInInsertMode(t *testing.T) {
+	m := editing(t)
+
+	_, cmd := update(m, tea.KeyMsg{Type: tea.KeyCtrlC})
+
+	if !quits(cmd) {
+		t.Errorf("Expected ctrl+c to quit while typing")
+	}
+}
+
+func TestViewFitsTerminal(t *testing.T) {
+	for _, size := range [][2]int{{120, 40}, {60, 20}, {20, 8}} {
+		m, _ := update(initialModel(&config.AppConfig{}), tea.WindowSizeMsg{Width: size[0], Height: size[1]})
+
+		for _, zoom := range []bool{false, true} {
+			m.layout.Zoomed = zoom
+			view := m.View()
+
+			if got := lipgloss.Height(view); got != size[1] {
+				t.Errorf("Expected height %d, got %d at %v", size[1], got, size)
+			}
+			if got := lipgloss.Width(view); got > size[0] {
+				t.Errorf("Expected width at most %d, got %d at %v", size[0], got, size)
+			}
+		}
+	}
+}
+
+func TestHelpScrollsToStatus(t *testing.T) {
+	m, _ := update(initialModel(&config.AppConfig{}), tea.WindowSizeMsg{Width: 60, Height: 20})
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+
+	if !m.showHelp || lipgloss.Height(m.View()) != 20 {
+		t.Fatalf("Expected the help to fit the terminal, got %d lines", lipgloss.Height(m.View()))
+	}
+	if strings.Contains(m.View(), "[7] Status") {
+		t.Errorf("Expected the Status section below the fold")
+	}
+
+	for idx := 0; idx < 200; idx++ {
+		m, _ = update(m, tea.KeyMsg{Type: tea.KeyDown})
+	}
+
+	if view := m.View(); !strings.Contains(view, "[7] Status") || lipgloss.Height(view) != 20 {
+		t.Errorf("Expected to scroll down to the Status section, got\n%s", view)
+	}
+	if want := keyhelp.MaxOffset(m.helpSections(), 60, 20); m.helpOffset != want {
+		t.Errorf("Expected the scroll to stop at %d, got %d", want, m.helpOffset)
+	}
+
+	m, _ = update(m, mouse(0, 0, tea.MouseActionPress, tea.MouseButtonWheelUp))
+	if want := keyhelp.MaxOffset(m.helpSections(), 60, 20) - 1; m.helpOffset != want {
+		t.Errorf("Expected the wheel to scroll up to %d, got %d", want, m.helpOffset)
+	}
+}
+
+func mouse(x int, y int, action tea.MouseAction, button tea.MouseButton) tea.MouseMsg {
+	return tea.MouseMsg{X: x, Y: y, Action: action, Button: button}
+}
+
+func click(x int, y int) tea.MouseMsg {
+	return mouse(x, y, tea.MouseActionPress, tea.MouseButtonLeft)
+}
+
+func sized(t *testing.T) MainModel {
+	m, _ := update(initialModel(&config.AppConfig{}), tea.WindowSizeMsg{Width: 100, Height: 41})
+	return m
+}
+
+func TestClickSelectsTable(t *testing.T) {
+	m := sized(t)
+	m.selectedTab = QueryTab
+	m.tablesModel.Tables = []string{"authors", "books", "reviews"}
+
+	// The tables pane starts below the database pane, inside its border.
+	m, _ = update(m, click(5, 7+1+2))
+
+	if m.selectedTab != TablesTab {
+		t.Errorf("Expected %v, got %v", TablesTab, m.selectedTab)
+	}
+	if m.tablesModel.SelectedTableIndex != 2 {
+		t.Errorf("Expected 2, got %v", m.tablesModel.SelectedTableIndex)
+	}
+
+	m, _ = update(m, mouse(5, 20, tea.MouseActionPress, tea.MouseButtonWheelUp))
+
+	if m.tablesModel.SelectedTableIndex != 1 {
+		t.Errorf("Expected 1, got %v", m.tablesModel.SelectedTableIndex)
+	}
+}
+
+func TestClickMovesResultCursor(t *testing.T) {
+	m := sized(t)
+	m, _ = update(m, db.ExecuteResult{
+		Query:   "SELECT id, name FROM authors",
+		Columns: []string{"id", "name"},
+		Rows: []map[string]interface{}{
+			{"id": 1, "name": "Ann"},
+			{"id": 2, "name": "Bob"},
+		},
+	})
+
+	// Below the tab bar and header of the results pane, in the second column.
+	m, _ = update(m, click(28+2+12+3, 12+1+3))
+
+	if m.selectedTab != ResultTab {
+		t.Errorf("Expected %v, got %v", ResultTab, m.selectedTab)
+	}
+	if got := m.resultModel.Cursor(); got != (result.Cursor{Row: 1, Column: 1}) {
+		t.Errorf("Expected {1 1}, got %v", got)
+	}
+}
+
+func TestDragSidebarEdge(t *testing.T) {
+	m := sized(t)
+
+	m, _ = update(m, click(27, 20))
+	m, _ = update(m, mouse(40, 20, tea.MouseActionMotion, tea.MouseButtonLeft))
+	m, _ = update(m, mouse(40, 20, tea.MouseActionRelease, tea.MouseButtonNone))
+
+	if m.layout.SidebarWidth != 41 {
+		t.Errorf("Expected 41, got %v", m.layout.SidebarWidth)
+	}
+
+	m, _ = update(m, mouse(60, 20, tea.MouseActionMotion, tea.MouseButtonNone))
+
+	if m.layout.SidebarWidth != 41 {
+		t.Errorf("Expected the drag to end on release, got %v", m.layout.SidebarWidth)
+	}
+}
+
+func TestClickIgnoredWhileTyping(t *testing.T) {
+	m := sized(t)
+	m, _ = update(m, query.FocusOnQueryMsg{})
+	m, _ = update(m, click(5, 20))
+
+	if m.selectedTab != QueryTab || m.mode != InsertMode {
+		t.Errorf("Expected to keep editing the query, got tab %v in %v", m.selectedTab, m.mode)
+	}
+}
+
+func TestSlashSearchesResults(t *testing.T) {
+	m := sized(t)
+	m, _ = update(m, db.ExecuteResult{
+		Query:   "SELECT id FROM authors",
+		Columns: []string{"id"},
+		Rows:    []map[string]interface{}{{"id": 1}},
+	})
+	m.selectedTab = ResultTab
+
+	m, _ = update(m, runes("/"))
+
+	if m.mode != InsertMode || m.queryModel.Input.Focused() {
+		t.Errorf("Expected to type a search in the results, got %v with the query focused: %v", m.mode, m.queryModel.Input.Focused())
+	}
+}
+
+func TestDatabasePaneListsConnections(t *testing.T) {
+	cfg := &config.AppConfig{
+		Databases: []config.DatabaseConfig{{Name: "local"}, {Name: "staging"}},
+		Invalid: []config.InvalidDatabase{{
+			Name:     "broken",
+			Problems: config.Problems{{Path: "config.yml", Line: 8, Column: 11, Message: "port must be between 1 and 65535, got 0"}},
+		}},
+	}
+	m, _ := update(initialModel(cfg), tea.WindowSizeMsg{Width: 100, Height: 41})
+	m.selectedTab = DatabaseTab
+
+	view := m.View()
+	for _, want := range []string{"local", "staging", "broken: port"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the database pane", want)
+		}
+	}
+
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd := update(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if cmd == nil {
+		t.Fatalf("Expected enter to connect")
+	}
+
+	if pending, ok := find[db.ConnectionPending](cmd); !ok || pending.Config.Name != "staging" {
+		t.Errorf("Expected to connect to staging, got %v", pending)
+	}
+}
+
+func typeText(m MainModel, s string) MainModel {
+	for _, r := range s {
+		m, _ = update(m, runes(string(r)))
+	}
+	return m
+}
+
+func TestAddConnection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	os.WriteFile(path, []byte("# My connections\ndatabases:\n  - name: local # dev box\n"), 0o600)
+
+	cfg, err := config.Reload(&config.AppConfig{Files: []string{path}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := sized(t)
+	m.config = cfg
+	m.databaseModel = database.InitModel(cfg)
+	m.selectedTab = DatabaseTab
+
+	m, _ = update(m, runes("a"))
+
+	if !m.databaseModel.Editing() || m.mode != InsertMode {
+		t.Fatalf("Expected the connection form to open")
+	}
+
+	m = typeText(m, "local")
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyCtrlS})
+
+	if !strings.Contains(m.View(), `a connection named "local" already exists`) {
+		t.Errorf("Expected the duplicate name to be shown in the form")
+	}
+
+	m = typeText(m, "-2")
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyTab})
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyTab})
+	m = typeText(m, "db.internal")
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyTab})
+	m = typeText(m, "3307")
+
+	m, cmd := update(m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	saved, ok := find[database.SavedMsg](cmd)
+	if !ok || saved.Err != nil {
+		t.Fatalf("Expected the connection to be saved, got %+v", saved)
+	}
+
+	m, cmd = update(m, saved)
+	if m.databaseModel.Editing() {
+		t.Errorf("Expected the form to close once saved")
+	}
+
+	reloaded, ok := find[configReloadedMsg](cmd)
+	if !ok {
+		t.Fatalf("Expected the config to be reloaded")
+	}
+	m, _ = update(m, reloaded)
+
+	if want := "added local-2"; !strings.Contains(m.notice, want) {
+		t.Errorf("Expected %q, got %q", want, m.notice)
+	}
+
+	data, _ := os.ReadFile(path)
+	want := "# My connections\ndatabases:\n  - name: local # dev box\n  - name: local-2\n    host: db.internal\n    port: 3307\n"
+	if string(data) != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, data)
+	}
+}
+
+func TestImportConnection(t *testing.T) {
+	cfg := &config.AppConfig{Databases: []config.DatabaseConfig{{Name: "local", Host: "localhost", Port: 3306, User: "root"}}}
+	m, _ := update(initialModel(cfg), tea.WindowSizeMsg{Width: 100, Height: 41})
+	m.selectedTab = DatabaseTab
+
+	m, _ = update(m, database.DiscoveredMsg{Candidates: []importer.Candidate{
+		{Database: config.DatabaseConfig{Name: "db", Host: "localhost", Port: 3306, User: "root"}, Source: "docker-compose.yml: db"},
+		{Database: config.DatabaseConfig{Name: "pg", Host: "localhost", Port: 5432}, Source: ".pgpass:1", Unsupported: "Postgres is not supported yet"},
+		{Database: config.DatabaseConfig{Name: "maria", Host: "localhost", Port: 3307, User: "root"}, Source: "docker-compose.yml: maria"},
+	}})
+
+	if !strings.Contains(m.View(), "1 found, i to import") {
+		t.Errorf("Expected the pane to offer the new connection")
+	}
+
+	m, _ = update(m, runes("i"))
+	view := m.View()
+
+	for _, want := range []string{"already added", "Postgres is not supported yet", "docker-compose.yml: maria"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the import list", want)
+		}
+	}
+
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(m.View(), "Import connections") {
+		t.Errorf("Expected a connection that's already added not to open the form")
+	}
+
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if !strings.Contains(m.View(), "Import from docker-compose.yml: maria") || !m.databaseModel.Editing() {
+		t.Errorf("Expected the form with the found connection")
+	}
+
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.databaseModel.Editing() {
+		t.Errorf("Expected esc to close the form")
+	}
+}
+
+// openConnection is a connection with a pool that hasn't dialled yet.
+type openConnection struct {
+	config *config.DatabaseConfig
+	db     *sql.DB
+}
+
+func (c openConnection) Status() string                    { return "Connected" }
+func (c openConnection) GetConfig() *config.DatabaseConfig { return c.config }
+func (c openConnection) GetConnection() *sql.DB            { return c.db }
+
+func TestPingFailureReconnects(t *testing.T) {
+	cfg := &config.AppConfig{Databases: []config.DatabaseConfig{{Name: "local"}}}
+	m, _ := update(initialModel(cfg), tea.WindowSizeMsg{Width: 100, Height: 41})
+
+	pool, err := sql.Open("mysql", "root@tcp(127.0.0.1:1)/test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn := openConnection{config: &cfg.Databases[0], db: pool}
+	m, _ = update(m, db.Connection(conn))
+
+	m, _ = update(m, db.PingMsg{DB: pool, Latency: 1500 * time.Microsecond})
+	if !strings.Contains(m.View(), "Connected 1.5ms") {
+		t.Errorf("Expected the ping latency in the database pane")
+	}
+
+	// A reconnect for a connection that was switched away from is dropped.
+	m, _ = update(m, db.ConnectionError{})
+	if m.connection != db.Connection(conn) {
+		t.Errorf("Expected to keep the current connection, got %v", m.connection)
+	}
+
+	// A ping skipped while every connection runs a query keeps the pool.
+	m, cmd := update(m, db.PingMsg{DB: pool, Err: db.ErrPoolBusy})
+	if _, lost := find[db.ConnectionLost](cmd); lost || cmd == nil || m.connection != db.Connection(conn) {
+		t.Errorf("Expected a busy pool to be pinged again later, not lost")
+	}
+
+	m, cmd = update(m, db.PingMsg{DB: pool, Err: errors.New("invalid connection")})
+
+	lost, ok := find[db.ConnectionLost](cmd)
+	if !ok {
+		t.Fatalf("Expected the failed ping to lose the connection")
+	}
+
+	m, cmd = update(m, lost)
+	if m.connection.Status() != "Reconnecting in 1s: invalid connection" || cmd == nil {
+		t.Errorf("Expected to wait to reconnect, got %q", m.connection.Status())
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	theme "gosuite/theme"
+)
+
+// Mode works like a modal editor: in NormalMode single keys are shortcuts,
+// in InsertMode they go to the focused text input and only esc (or another
+// key that can't be typed) does anything else.
+type Mode int
+
+const (
+	NormalMode Mode = iota
+	InsertMode
+)
+
+func (mode Mode) String() string {
+	if mode == InsertMode {
+		return "INSERT"
+	}
+	return "NORMAL"
+}
+
+func (mode Mode) View() string {
+	style := lipgloss.NewStyle().Bold(true).Padding(0, 1).
+		Foreground(theme.Current.SelectionForeground).Background(theme.Current.Accent)
+
+	if mode == InsertMode {
+		style = style.Background(theme.Current.Warning)
+	}
+
+	return style.Render(mode.String())
+}
+
+// inputMode derives the mode from whichever text input has focus.
+func (m MainModel) inputMode() Mode {
+	if m.queryModel.Input.Focused() || m.processModel.Filtering() || m.resultModel.Filtering() || m.databaseModel.Editing() {
+		return InsertMode
+	}
+	return NormalMode
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	design "gosuite/design"
+	layout "gosuite/layout"
+	keyhelp "gosuite/views/keyhelp"
+)
+
+// tabFor is the tab shown in region.
+func (m MainModel) tabFor(region layout.Region) int {
+	switch region {
+	case layout.Database:
+		return DatabaseTab
+	case layout.Tables:
+		return TablesTab
+	case layout.Query:
+		return QueryTab
+	default:
+		return m.lowerTab
+	}
+}
+
+// updateMouse drags pane borders, focuses the pane that was clicked and
+// passes clicks and the scroll wheel on to the pane under the pointer.
+func (m MainModel) updateMouse(msg tea.MouseMsg) (MainModel, tea.Cmd) {
+	if m.showHelp {
+		wheel := design.MouseMsg{MouseMsg: msg}.Scroll()
+		m.helpOffset = min(max(m.helpOffset+wheel, 0), keyhelp.MaxOffset(m.helpSections(), m.terminalWidth, m.terminalHeight))
+		return m, nil
+	}
+
+	if m.confirmModel.Active() || m.databaseModel.Editing() {
+		return m, nil
+	}
+
+	areas := m.areas()
+	clicked := msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft
+
+	switch {
+	case msg.Action == tea.MouseActionRelease:
+		m.drag = layout.NoEdge
+		return m, nil
+
+	case m.drag != layout.NoEdge:
+		if msg.Action == tea.MouseActionMotion {
+			m.layout = m.layout.Drag(m.drag, msg.X, msg.Y).Clamp(m.terminalWidth, m.terminalHeight-1)
+		}
+		return m, nil
+
+	case clicked && m.mode == NormalMode:
+		if m.drag = areas.EdgeAt(msg.X, msg.Y); m.drag != layout.NoEdge {
+			return m, nil
+		}
+	}
+
+	region, ok := areas.At(msg.X, msg.Y)
+	if !ok || !(clicked || tea.MouseEvent(msg).IsWheel()) {
+		return m, nil
+	}
+
+	// Finish typing before moving on, just as with the keyboard.
+	if clicked && m.mode == InsertMode && region != m.focusedRegion() {
+		return m, nil
+	}
+	if clicked {
+		m.selectedTab = m.tabFor(region)
+	}
+
+	rect := areas[region]
+	local := design.MouseMsg{MouseMsg: msg, Width: rect.Width, Height: rect.Height}
+	local.X -= rect.X
+	local.Y -= rect.Y
+
+	var cmd tea.Cmd
+
+	switch m.tabFor(region) {
+	case DatabaseTab:
+		m.databaseModel, cmd = m.databaseModel.Update(local, true)
+	case TablesTab:
+		m.tablesModel, cmd = m.tablesModel.Update(local, true, &m.connection)
+	case QueryTab:
+		m.queryModel, cmd = m.queryModel.Update(local, true, &m.connection)
+	case ResultTab:
+		m.resultModel, cmd = m.resultModel.Update(local, true, &m.connection)
+	case ExplainTab:
+		m.explainModel, cmd = m.explainModel.Update(local, true)
+	case ProcessTab:
+		m.processModel, cmd = m.processModel.Update(local, true, &m.connection)
+	}
+
+	m.mode = m.inputMode()
+
+	return m, cmd
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+
+	db "gosuite/db"
+)
+
+var formats = []string{"table", "csv", "json", "ndjson"}
+
+func validFormat(format string) bool {
+	for _, f := range formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+func writeResult(w io.Writer, format string, result db.ExecuteResult) error {
+	switch format {
+	case "csv":
+		return writeCSV(w, result)
+	case "json":
+		return writeJSON(w, result)
+	case "ndjson":
+		return writeNDJSON(w, result)
+	}
+	return writeTable(w, result)
+}
+
+func formatCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05")
+	}
+	return fmt.Sprint(value)
+}
+
+// writeTable prints the rows as plain text columns lined up for reading in a
+// terminal.
+func writeTable(w io.Writer, result db.ExecuteResult) error {
+	if len(result.Columns) == 0 {
+		_, err := fmt.Fprintln(w, "OK")
+		return err
+	}
+
+	widths := make([]int, len(result.Columns))
+	for idx, column := range result.Columns {
+		widths[idx] = runewidth.StringWidth(column)
+	}
+
+	cells := make([][]string, len(result.Rows))
+	for row, data := range result.Rows {
+		cells[row] = make([]string, len(result.Columns))
+		for idx, column := range result.Columns {
+			// Newlines and tabs would break the alignment.
+			cell := strings.NewReplacer("\n", `\n`, "\t", `\t`).Replace(formatCell(data[column]))
+			cells[row][idx] = cell
+			widths[idx] = max(widths[idx], runewidth.StringWidth(cell))
+		}
+	}
+
+	line := func(values []string) string {
+		padded := make([]string, len(values))
+		for idx, value := range values {
+			padded[idx] = runewidth.FillRight(value, widths[idx])
+		}
+		return strings.TrimRight(strings.Join(padded, "  "), " ")
+	}
+
+	rules := make([]string, len(widths))
+	for idx, width := range widths {
+		rules[idx] = strings.Repeat("-", width)
+	}
+
+	lines := []string{line(result.Columns), line(rules)}
+	for _, row := range cells {
+		lines = append(lines, line(row))
+	}
+
+	noun := "rows"
+	if len(result.Rows) == 1 {
+		noun = "row"
+	}
+	lines = append(lines, fmt.Sprintf("(%d %s)", len(result.Rows), noun))
+
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+// writeCSV writes a header and the rows, NULL as an empty field.
+func writeCSV(w io.Writer, result db.ExecuteResult) error {
+	if len(result.Columns) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(result.Columns); err != nil {
+		return err
+	}
+
+	for _, data := range result.Rows {
+		record := make([]string, len(result.Columns))
+		for idx, column := range result.Columns {
+			if data[column] != nil {
+				record[idx] = formatCell(data[column])
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// jsonRow encodes a row as an object with its keys in column order, which
+// encoding a map would lose.
+func jsonRow(columns []string, data map[string]interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	for idx, column := range columns {
+		if idx > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(column)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(data[column])
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// jsonArray encodes the rows as an array with one row per line, each line
+// indented by indent.
+func jsonArray(result db.ExecuteResult, indent string) (string, error) {
+	if len(result.Rows) == 0 {
+		return "[]", nil
+	}
+
+	lines := make([]string, len(result.Rows))
+	for idx, data := range result.Rows {
+		row, err := jsonRow(result.Columns, data)
+		if err != nil {
+			return "", err
+		}
+		lines[idx] = indent + "  " + string(row)
+	}
+
+	return "[\n" + strings.Join(lines, ",\n") + "\n" + indent + "]", nil
+}
+
+// writeJSON writes the rows as one array.
+func writeJSON(w io.Writer, result db.ExecuteResult) error {
+	if len(result.Columns) == 0 {
+		return nil
+	}
+
+	array, err := jsonArray(result, "")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, array)
+	return err
+}
+
+// writeJSONSets writes the result sets of several statements as one
+// document: a lone set as its array, more as an array of arrays. Statements
+// without columns are left out.
+func writeJSONSets(w io.Writer, results []db.ExecuteResult) error {
+	sets := make([]db.ExecuteResult, 0, len(results))
+	for _, result := range results {
+		if len(result.Columns) > 0 {
+			sets = append(sets, result)
+		}
+	}
+
+	switch len(sets) {
+	case 0:
+		return nil
+	case 1:
+		return writeJSON(w, sets[0])
+	}
+
+	arrays := make([]string, len(sets))
+	for idx, result := range sets {
+		array, err := jsonArray(result, "  ")
+		if err != nil {
+			return err
+		}
+		arrays[idx] = "  " + array
+	}
+
+	_, err := fmt.Fprintf(w, "[\n%s\n]\n", strings.Join(arrays, ",\n"))
+	return err
+}
+
+// writeNDJSON writes one object per line.
+func writeNDJSON(w io.Writer, result db.ExecuteResult) error {
+	for _, data := range result.Rows {
+		row, err := jsonRow(result.Columns, data)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(w, string(row)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
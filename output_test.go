@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	db "gosuite/db"
+)
+
+func authors() db.ExecuteResult {
+	return db.ExecuteResult{
+		Query:   "SELECT id, name, bio FROM authors",
+		Columns: []string{"name", "id", "bio"},
+		Rows: []map[string]interface{}{
+			{"id": int64(1), "name": "Ann", "bio": nil},
+			{"id": int64(22), "name": "Bob, Jr.", "bio": "two\nlines"},
+		},
+	}
+}
+
+func TestWriteResult(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"table", "name      id  bio\n--------  --  ----------\nAnn       1   NULL\nBob, Jr.  22  two\\nlines\n(2 rows)\n"},
+		{"csv", "name,id,bio\nAnn,1,\n\"Bob, Jr.\",22,\"two\nlines\"\n"},
+		{"json", "[\n  {\"name\":\"Ann\",\"id\":1,\"bio\":null},\n  {\"name\":\"Bob, Jr.\",\"id\":22,\"bio\":\"two\\nlines\"}\n]\n"},
+		{"ndjson", "{\"name\":\"Ann\",\"id\":1,\"bio\":null}\n{\"name\":\"Bob, Jr.\",\"id\":22,\"bio\":\"two\\nlines\"}\n"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := writeResult(&buf, tt.format, authors()); err != nil {
+			t.Fatalf("Expected no error for %s, got %v", tt.format, err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("Expected %s output %q, got %q", tt.format, tt.want, buf.String())
+		}
+	}
+}
+
+func TestWriteEmptyResult(t *testing.T) {
+	var buf bytes.Buffer
+	writeResult(&buf, "json", db.ExecuteResult{Columns: []string{"id"}})
+
+	if buf.String() != "[]\n" {
+		t.Errorf("Expected an empty array, got %q", buf.String())
+	}
+
+	buf.Reset()
+	writeResult(&buf, "table", db.ExecuteResult{})
+
+	if buf.String() != "OK\n" {
+		t.Errorf("Expected OK for a statement without rows, got %q", buf.String())
+	}
+}
+
+func TestWriteJSONSets(t *testing.T) {
+	var buf bytes.Buffer
+	sets := []db.ExecuteResult{
+		{Columns: []string{"id"}, Rows: []map[string]interface{}{{"id": 1}}},
+		{},
+		{Columns: []string{"id"}},
+	}
+
+	if err := writeJSONSets(&buf, sets); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := "[\n  [\n    {\"id\":1}\n  ],\n  []\n]\n"
+	if buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+
+	var decoded [][]map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil || len(decoded) != 2 {
+		t.Errorf("Expected one document with 2 result sets, got %d (%v)", len(decoded), err)
+	}
+
+	buf.Reset()
+	writeJSONSets(&buf, sets[:2])
+
+	if buf.String() != "[\n  {\"id\":1}\n]\n" {
+		t.Errorf("Expected a lone result set as its array, got %q", buf.String())
+	}
+}
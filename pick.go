@@ -0,0 +1,303 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	db "gosuite/db"
+	keymap "gosuite/keymap"
+	result "gosuite/result"
+	"gosuite/services/config"
+	theme "gosuite/theme"
+)
+
+// exitCancelled is returned when nothing was picked, as fzf does.
+const exitCancelled = 130
+
+type pickOptions struct {
+	config     string
+	connection string
+	table      string
+	sql        string
+	column     string
+}
+
+func parsePickArgs(args []string, stderr io.Writer) (pickOptions, error) {
+	opts := pickOptions{}
+
+	flags := flag.NewFlagSet("pick", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: gosuite pick [-c connection] (-t table | -e SQL) [--column name]")
+		fmt.Fprintln(stderr, "Prints the picked rows as JSON, one per line, or just the chosen column.")
+		flags.PrintDefaults()
+	}
+
+	flags.StringVar(&opts.config, "config", "", "config `file` to use instead of the global one")
+	flags.StringVar(&opts.connection, "c", "", "connection `name` from the config, optional with a single connection")
+	flags.StringVar(&opts.connection, "connection", "", "same as -c")
+	flags.StringVar(&opts.table, "t", "", "`table` to browse")
+	flags.StringVar(&opts.sql, "e", "", "read-only `SQL` to pick from")
+	flags.StringVar(&opts.column, "column", "", "print only this `column` of the picked rows")
+
+	if err := flags.Parse(args); err != nil {
+		return opts, err
+	}
+
+	if flags.NArg() > 0 {
+		return opts, fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+
+	if (opts.table == "") == (opts.sql == "") {
+		return opts, errors.New("use either -t or -e")
+	}
+
+	if opts.sql != "" && !db.IsReadOnly(opts.sql) {
+		return opts, errors.New("pick only runs SELECT, SHOW, EXPLAIN and DESCRIBE")
+	}
+
+	return opts, nil
+}
+
+// pickModel shows the Results grid on its own and ends the program once
+// rows are picked.
+type pickModel struct {
+	opts       pickOptions
+	connection db.Connection
+	result     result.Model
+
+	width    int
+	height   int
+	selected map[int]bool
+
+	picked    []map[string]interface{}
+	cancelled bool
+}
+
+func newPickModel(opts pickOptions, connection db.Connection) pickModel {
+	return pickModel{
+		opts:       opts,
+		connection: connection,
+		result:     result.InitModel(),
+		selected:   make(map[int]bool),
+		cancelled:  true,
+	}
+}
+
+func (m pickModel) Init() tea.Cmd {
+	if m.opts.table != "" {
+		return result.OpenTable(m.opts.table)
+	}
+	return db.ExucuteSQLCmd(m.opts.sql, m.connection.GetConnection())
+}
+
+// pick chooses the selected rows, or the one under the cursor if none are.
+func (m pickModel) pick() pickModel {
+	rows := m.result.Result().Rows
+
+	m.picked = make([]map[string]interface{}, 0, len(m.selected))
+	for idx := range rows {
+		if m.selected[idx] {
+			m.picked = append(m.picked, rows[idx])
+		}
+	}
+
+	if idx, ok := m.result.RowIndex(); ok && len(m.picked) == 0 {
+		m.picked = append(m.picked, rows[idx])
+	}
+
+	m.cancelled = len(m.picked) == 0
+	return m
+}
+
+func (m pickModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+
+	case db.ExecuteRequestMsg:
+		// Browsing pages through the table asks for more queries.
+		if !db.IsReadOnly(msg.Query) {
+			err := fmt.Errorf("%w: pick only reads", db.ErrReadOnly)
+			return m, func() tea.Msg { return db.ExecuteError{Query: msg.Query, Err: err} }
+		}
+		return m, db.ExucuteSQLCmd(msg.Query, m.connection.GetConnection())
+
+	case tea.KeyMsg:
+		// Keys typed into a filter or the foreign key menu belong to it.
+		if m.result.Filtering() || m.result.Picking() {
+			if msg.Type == tea.KeyCtrlC {
+				return m, tea.Quit
+			}
+			break
+		}
+
+		switch {
+		case key.Matches(msg, keymap.Keys.Dialog.Cancel, keymap.Keys.Global.Quit):
+			return m, tea.Quit
+		case m.result.Result() == nil:
+			return m, nil
+		case key.Matches(msg, keymap.Keys.Pick.Pick):
+			return m.pick(), tea.Quit
+		case key.Matches(msg, keymap.Keys.Pick.Toggle):
+			if idx, ok := m.result.RowIndex(); ok {
+				m.selected[idx] = !m.selected[idx]
+			}
+			return m, nil
+		}
+	}
+
+	shown := m.result.Result()
+	m.result, cmd = m.result.Update(msg, true, &m.connection)
+
+	// The selection indexes the rows shown, so a new result or another tab
+	// starts a new one.
+	if m.result.Result() != shown {
+		m.selected = make(map[int]bool)
+	}
+
+	return m, cmd
+}
+
+func (m pickModel) View() string {
+	keys := keymap.Keys
+
+	count := 0
+	for _, selected := range m.selected {
+		if selected {
+			count++
+		}
+	}
+
+	status := fmt.Sprintf("%s %s · %s %s · %s %s",
+		keys.Pick.Pick.Help().Key, keys.Pick.Pick.Help().Desc,
+		keys.Pick.Toggle.Help().Key, keys.Pick.Toggle.Help().Desc,
+		keys.Dialog.Cancel.Help().Key, keys.Dialog.Cancel.Help().Desc,
+	)
+	if count > 0 {
+		status = fmt.Sprintf("%d selected · %s", count, status)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		m.result.SetPicked(m.selected).View(true, m.width, max(m.height-1, 0)),
+		lipgloss.NewStyle().MaxWidth(m.width).Render(theme.Style(theme.Current.Muted).Render(status)),
+	)
+}
+
+// writePicked prints each row as a JSON object, or only column when set,
+// one per line.
+func writePicked(w io.Writer, columns []string, rows []map[string]interface{}, column string) error {
+	if column != "" {
+		found := false
+		for _, c := range columns {
+			found = found || c == column
+		}
+		if !found {
+			return fmt.Errorf("no column %q in %s", column, strings.Join(columns, ", "))
+		}
+	}
+
+	for _, row := range rows {
+		if column != "" {
+			value := ""
+			if row[column] != nil {
+				value = formatCell(row[column])
+			}
+			if _, err := fmt.Fprintln(w, value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		data, err := jsonRow(columns, row)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(w, string(data)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runPick runs `gosuite pick`, drawing on the terminal directly so stdout
+// is left for the picked rows.
+func runPick(configPath string, args []string, stdout io.Writer, stderr io.Writer) int {
+	opts, err := parsePickArgs(args, stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+
+	if opts.config == "" {
+		opts.config = configPath
+	}
+
+	cfg, err := config.GetConfig(opts.config)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+
+	info, err := findDatabase(cfg, opts.connection)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: pick needs a terminal: %v\n", err)
+		return exitUsage
+	}
+	defer tty.Close()
+
+	lipgloss.SetDefaultRenderer(lipgloss.NewRenderer(tty))
+
+	if err := loadKeysAndTheme(cfg); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+
+	connection := db.ConnectCmd(info)().(db.Connection)
+	if connection.GetConnection() == nil {
+		fmt.Fprintf(stderr, "Error: connecting to %s: %s\n", info.Name, connection.Status())
+		return exitConnection
+	}
+	defer connection.GetConnection().Close()
+
+	p := tea.NewProgram(newPickModel(opts, connection), tea.WithAltScreen(), tea.WithInput(tty), tea.WithOutput(tty))
+
+	final, err := p.Run()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+
+	m := final.(pickModel)
+	if m.cancelled {
+		return exitCancelled
+	}
+
+	if err := writePicked(stdout, m.result.Result().Columns, m.picked, opts.column); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+
+	return exitOK
+}
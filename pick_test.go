@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	db "gosuite/db"
+	config "gosuite/services/config"
+)
+
+func TestParsePickArgs(t *testing.T) {
+	opts, err := parsePickArgs([]string{"-c", "prod", "-t", "authors", "--column", "id"}, io.Discard)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if opts.connection != "prod" || opts.table != "authors" || opts.column != "id" {
+		t.Errorf("Expected prod, authors and id, got %+v", opts)
+	}
+
+	for _, args := range [][]string{
+		{"-c", "prod"},
+		{"-t", "authors", "-e", "SELECT 1"},
+		{"-e", "DELETE FROM authors"},
+	} {
+		if _, err := parsePickArgs(args, io.Discard); err == nil {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+}
+
+func picking(t *testing.T) pickModel {
+	var m tea.Model = newPickModel(pickOptions{sql: "SELECT * FROM authors"}, db.ConnectionPending{})
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 20})
+	m, _ = m.Update(authors())
+	return m.(pickModel)
+}
+
+func TestPickCurrentRow(t *testing.T) {
+	var m tea.Model = picking(t)
+
+	if view := m.View(); !strings.Contains(view, "Bob, Jr.") || lipgloss.Height(view) != 20 {
+		t.Errorf("Expected the rows drawn over the whole terminal, got %d lines", lipgloss.Height(view))
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if !quits(cmd) {
+		t.Errorf("Expected enter to end the program")
+	}
+
+	picked := m.(pickModel)
+	if picked.cancelled || len(picked.picked) != 1 || picked.picked[0]["id"] != int64(22) {
+		t.Errorf("Expected to pick id 22, got %v", picked.picked)
+	}
+}
+
+func TestPickSelectedRows(t *testing.T) {
+	var m tea.Model = picking(t)
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	picked := m.(pickModel)
+
+	var buf bytes.Buffer
+	if err := writePicked(&buf, authors().Columns, picked.picked, "id"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if buf.String() != "1\n22\n" {
+		t.Errorf("Expected both ids, got %q", buf.String())
+	}
+
+	if err := writePicked(&buf, authors().Columns, picked.picked, "missing"); err == nil {
+		t.Errorf("Expected an error for an unknown column")
+	}
+}
+
+func TestPickCancel(t *testing.T) {
+	var m tea.Model = picking(t)
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	if !quits(cmd) || !m.(pickModel).cancelled {
+		t.Errorf("Expected esc to end the program without picking")
+	}
+}
+
+// keysDriver answers the key lookups for authors, which posts and books both
+// reference.
+type keysDriver struct{}
+
+func (d keysDriver) Connect(context.Context) (driver.Conn, error) { return d, nil }
+func (d keysDriver) Driver() driver.Driver                        { return nil }
+func (d keysDriver) Prepare(query string) (driver.Stmt, error)    { return keysStmt(query), nil }
+func (d keysDriver) Close() error                                 { return nil }
+func (d keysDriver) Begin() (driver.Tx, error)                    { return nil, driver.ErrSkip }
+
+type keysStmt string
+
+func (s keysStmt) Close() error                               { return nil }
+func (s keysStmt) NumInput() int                              { return -1 }
+func (s keysStmt) Exec([]driver.Value) (driver.Result, error) { return nil, driver.ErrSkip }
+
+func (s keysStmt) Query([]driver.Value) (driver.Rows, error) {
+	if strings.Contains(string(s), "'PRIMARY'") {
+		return &keysRows{columns: []string{"COLUMN_NAME"}, rows: [][]driver.Value{{"id"}}}, nil
+	}
+	return &keysRows{
+		columns: []string{"CONSTRAINT_NAME", "TABLE_NAME", "COLUMN_NAME", "REFERENCED_TABLE_NAME", "REFERENCED_COLUMN_NAME"},
+		rows: [][]driver.Value{
+			{"books_author", "books", "author_id", "authors", "id"},
+			{"posts_author", "posts", "author_id", "authors", "id"},
+		},
+	}, nil
+}
+
+type keysRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *keysRows) Columns() []string { return r.columns }
+func (r *keysRows) Close() error      { return nil }
+
+func (r *keysRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+type keysConnection struct{ conn *sql.DB }
+
+func (c keysConnection) Status() string                    { return "Connected" }
+func (c keysConnection) GetConfig() *config.DatabaseConfig { return &config.DatabaseConfig{} }
+func (c keysConnection) GetConnection() *sql.DB            { return c.conn }
+
+func TestPickLeavesKeysToReferenceMenu(t *testing.T) {
+	var m tea.Model = newPickModel(pickOptions{table: "authors"}, keysConnection{sql.OpenDB(keysDriver{})})
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 20})
+
+	// Open the table, which looks up its keys and then asks for its rows.
+	m, cmd := m.Update(m.Init()())
+	m, cmd = m.Update(cmd())
+	request := cmd().(db.ExecuteRequestMsg)
+
+	rows := authors()
+	rows.Query = request.Query
+	m, _ = m.Update(rows)
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+
+	if !m.(pickModel).result.Picking() {
+		t.Fatalf("Expected the menu of referencing tables to open")
+	}
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if quits(cmd) || len(m.(pickModel).picked) != 0 {
+		t.Errorf("Expected enter to choose from the menu, not pick the row")
+	}
+	if m.(pickModel).result.Picking() || cmd == nil {
+		t.Errorf("Expected enter to open the referencing table")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	if quits(cmd) || m.(pickModel).result.Picking() {
+		t.Errorf("Expected esc to close the menu and keep picking")
+	}
+}
+
+func TestPickSelectionFollowsTab(t *testing.T) {
+	var m tea.Model = picking(t)
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+
+	if view := m.View(); !strings.Contains(view, "*Ann") || strings.Contains(view, "*Bob") {
+		t.Errorf("Expected only Ann marked as picked, got\n%s", view)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+
+	if strings.Contains(m.View(), "*Ann") {
+		t.Errorf("Expected Ann unmarked once toggled again")
+	}
+
+	// Rows picked in another tab don't carry over.
+	m, _ = m.Update(db.ExecuteResult{Query: "SELECT 5 AS id", Columns: []string{"id"}, Rows: []map[string]interface{}{{"id": int64(5)}}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("[")})
+
+	if strings.Contains(m.View(), "*") {
+		t.Errorf("Expected no rows marked after switching tabs")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	picked := m.(pickModel).picked
+	if len(picked) != 1 || picked[0]["id"] != int64(22) {
+		t.Errorf("Expected only the row under the cursor, got %v", picked)
+	}
+}
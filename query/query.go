@@ -2,25 +2,26 @@ package query
 
 import (
 	"regexp"
+	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 
 	db "gosuite/db"
 	design "gosuite/design"
-)
-
-var (
-	keywordStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("140"))
-	stringStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
-	commentStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
-	functionStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("198"))
-	operatorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("198"))
-	identifierStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("87"))
+	keymap "gosuite/keymap"
+	theme "gosuite/theme"
 )
 
 func sqlHighlighter(sql string) string {
+	keywordStyle := theme.Style(theme.Current.Keyword).Bold(true)
+	stringStyle := theme.Style(theme.Current.String)
+	commentStyle := theme.Style(theme.Current.Comment)
+	functionStyle := theme.Style(theme.Current.Function)
+	operatorStyle := theme.Style(theme.Current.Operator)
+	identifierStyle := theme.Style(theme.Current.Identifier)
+
 	// Define regular expressions for different SQL syntax elements
 	keywordRegex := regexp.MustCompile(
 		`\b(?i)(SELECT|FROM|WHERE|JOIN|GROUP BY|ORDER BY|LIMIT|OFFSET|INSERT INTO|UPDATE|DELETE|CREATE|DROP|ALTER|TABLE|VIEW|INDEX|TRIGGER|PROCEDURE|FUNCTION)\b`,
@@ -74,6 +75,22 @@ func InitModel() Model {
 	}
 }
 
+// CurrentStatement returns the statement under the editor's cursor.
+func (m Model) CurrentStatement() string {
+	value := m.Input.Value()
+	lines := strings.Split(value, "\n")
+
+	offset := 0
+	for idx := 0; idx < m.Input.Line() && idx < len(lines); idx++ {
+		offset += len([]rune(lines[idx])) + 1
+	}
+
+	info := m.Input.LineInfo()
+	offset += info.StartColumn + info.CharOffset
+
+	return db.StatementAt(value, offset)
+}
+
 func FocusOnQuery() tea.Cmd {
 	return func() tea.Msg {
 		return FocusOnQueryMsg{}
@@ -93,21 +110,37 @@ func (m Model) Update(msg tea.Msg, active bool, conn *db.Connection) (Model, tea
 	switch msg := msg.(type) {
 	case db.ExecuteResult:
 		m.Input.SetValue(msg.Query)
+	case db.ExecuteError:
+		m.Input.SetValue(msg.Query)
 	case FocusOnQueryMsg:
 		m.Input.Focus()
+	case design.MouseMsg:
+		if _, _, ok := msg.Content(); ok && msg.Clicked() && !m.Input.Focused() {
+			cmd = m.Input.Focus()
+			cmds = append(cmds, cmd)
+		}
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "enter":
+		switch {
+		case key.Matches(msg, keymap.Keys.Query.Execute):
 			if active && !m.Input.Focused() {
-				// TODO: Resurrect this
-				// cmd = db.ExucuteSQLCmd(m.Input.Value(), conn)
-				// cmds = append(cmds, cmd)
+				cmd = db.RequestExecute(m.Input.Value())
+				cmds = append(cmds, cmd)
+			} else if active {
+				m.Input, cmd = m.Input.Update(msg)
+				cmds = append(cmds, cmd)
 			}
-		case "esc":
+		case key.Matches(msg, keymap.Keys.Query.Blur):
 			if m.Input.Focused() {
 				m.Input.Blur()
 			}
 
+		case key.Matches(msg, keymap.Keys.Query.Edit) && active && !m.Input.Focused():
+			cmd = m.Input.Focus()
+			cmds = append(cmds, cmd)
+
+		case key.Matches(msg, keymap.Keys.Query.Indent) && m.Input.Focused():
+			m.Input.InsertString("  ")
+
 		default:
 			if active {
 				m.Input, cmd = m.Input.Update(msg)
@@ -121,5 +154,26 @@ func (m Model) Update(msg tea.Msg, active bool, conn *db.Connection) (Model, tea
 }
 
 func (m Model) View(selected bool, width int, height int) string {
-	return design.CreatePane(3, "Query", selected, width, height, sqlHighlighter(m.Input.View()))
+	contentWidth, contentHeight := design.ContentSize(width, height)
+
+	input := m.Input
+	input.SetWidth(contentWidth)
+	input.SetHeight(contentHeight)
+
+	return design.Pane{Index: 3, Title: "Query", Selected: selected}.Render(width, height, sqlHighlighter(input.View()))
+}
+
+func (m Model) ShortHelp() []key.Binding {
+	keys := keymap.Keys.Query
+
+	if m.Input.Focused() {
+		return []key.Binding{keys.Blur, keys.Indent, keymap.Keys.Global.Explain}
+	}
+	return []key.Binding{keys.Execute, keys.Edit, keymap.Keys.Global.Explain}
+}
+
+func (m Model) FullHelp() [][]key.Binding {
+	keys := keymap.Keys.Query
+
+	return [][]key.Binding{{keys.Execute, keys.Edit, keys.Blur, keys.Indent, keymap.Keys.Global.Explain}}
 }
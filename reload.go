@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"gosuite/services/config"
+	"gosuite/theme"
+)
+
+// configPollInterval is a variable so tests can poll without waiting.
+var configPollInterval = 2 * time.Second
+
+// configStamps are the modification times of the config files.
+type configStamps map[string]time.Time
+
+func statConfig(files []string) configStamps {
+	stamps := make(configStamps, len(files))
+
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			stamps[file] = info.ModTime()
+		}
+	}
+
+	return stamps
+}
+
+func (s configStamps) changed(other configStamps) bool {
+	if len(s) != len(other) {
+		return true
+	}
+	for file, stamp := range s {
+		if !other[file].Equal(stamp) {
+			return true
+		}
+	}
+	return false
+}
+
+type configPollMsg struct {
+	stamps configStamps
+}
+
+type configReloadedMsg struct {
+	config *config.AppConfig
+	err    error
+	// polled is set for reloads started by the poll loop, which goes on
+	// once they're done. A reload after a save leaves the loop running.
+	polled bool
+}
+
+// watchConfig reads the modification times of the config files once the
+// poll interval has passed.
+func watchConfig(cfg *config.AppConfig) tea.Cmd {
+	if len(cfg.Files) == 0 {
+		return nil
+	}
+
+	return tea.Tick(configPollInterval, func(time.Time) tea.Msg {
+		return configPollMsg{stamps: statConfig(cfg.Files)}
+	})
+}
+
+func reloadConfig(cfg *config.AppConfig, polled bool) tea.Cmd {
+	return func() tea.Msg {
+		reloaded, err := config.Reload(cfg)
+		return configReloadedMsg{config: reloaded, err: err, polled: polled}
+	}
+}
+
+// rewatch carries on the poll loop after a reload it started.
+func (m MainModel) rewatch(msg configReloadedMsg) tea.Cmd {
+	if !msg.polled {
+		return nil
+	}
+	return watchConfig(m.config)
+}
+
+// poll reloads the config if its files changed since the last poll.
+func (m MainModel) poll(msg configPollMsg) (MainModel, tea.Cmd) {
+	if !msg.stamps.changed(m.configStamps) {
+		return m, watchConfig(m.config)
+	}
+
+	m.configStamps = msg.stamps
+	return m, reloadConfig(m.config, true)
+}
+
+// reload switches to the reloaded config and sums it up in the notice.
+func (m MainModel) reload(msg configReloadedMsg) (MainModel, tea.Cmd) {
+	if msg.err != nil {
+		m.notice = theme.Style(theme.Current.Error).Render("Could not reload the config: " + msg.err.Error())
+		return m, m.rewatch(msg)
+	}
+
+	changes := config.DiffDatabases(m.config.Databases, msg.config.Databases)
+	m.config = msg.config
+
+	var cmd tea.Cmd
+	m.databaseModel, cmd = m.databaseModel.Reload(m.config)
+
+	if problems := m.config.Problems; len(problems) > 0 {
+		count := "1 problem"
+		if len(problems) > 1 {
+			count = fmt.Sprintf("%d problems", len(problems))
+		}
+		m.notice = theme.Style(theme.Current.Warning).Render(
+			fmt.Sprintf("Reloaded the config with %s, first %s", count, problems[0].Error()),
+		)
+	} else {
+		m.notice = "Reloaded the config: " + changes.String()
+	}
+
+	return m, tea.Batch(cmd, m.rewatch(msg))
+}
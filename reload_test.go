@@ -0,0 +1,134 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	db "gosuite/db"
+	"gosuite/services/config"
+	"gosuite/views/database"
+)
+
+func TestPollReloadsChangedFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	os.WriteFile(path, []byte("databases:\n  - name: local\n"), 0o600)
+
+	m := initialModel(&config.AppConfig{Path: path, Files: []string{path}})
+
+	if _, cmd := m.poll(configPollMsg{stamps: statConfig(m.config.Files)}); cmd == nil {
+		t.Fatalf("Expected to keep polling")
+	}
+
+	later := time.Now().Add(time.Minute)
+	os.Chtimes(path, later, later)
+
+	m, cmd := m.poll(configPollMsg{stamps: statConfig(m.config.Files)})
+
+	reloaded, ok := cmd().(configReloadedMsg)
+	if !ok || reloaded.err != nil || len(reloaded.config.Databases) != 1 {
+		t.Fatalf("Expected the config to be reloaded, got %+v", reloaded)
+	}
+	if !m.configStamps[path].Equal(later) {
+		t.Errorf("Expected the new modification time, got %v", m.configStamps[path])
+	}
+}
+
+func TestReloadReconnectsChangedConnection(t *testing.T) {
+	cfg := &config.AppConfig{Databases: []config.DatabaseConfig{{Name: "local", Port: 3306}, {Name: "old"}}}
+	m, _ := update(initialModel(cfg), db.ConnectionPending{Config: &cfg.Databases[0]})
+
+	m, cmd := update(m, configReloadedMsg{config: &config.AppConfig{
+		Databases: []config.DatabaseConfig{{Name: "local", Port: 3307}, {Name: "new"}},
+	}})
+
+	if want := "added new; changed local; removed old"; !strings.Contains(m.notice, want) {
+		t.Errorf("Expected %q, got %q", want, m.notice)
+	}
+	if pending, ok := find[db.ConnectionPending](cmd); !ok || pending.Config.Port != 3307 {
+		t.Errorf("Expected to reconnect to local on 3307, got %v", pending)
+	}
+
+	m, cmd = update(m, configReloadedMsg{config: &config.AppConfig{
+		Databases: []config.DatabaseConfig{{Name: "local", Port: 3307}},
+		Problems:  config.Problems{{Path: "config.yml", Line: 3, Column: 5, Message: `unknown key "prot"`}},
+	}})
+
+	if !strings.Contains(m.notice, `1 problem, first config.yml:3:5: unknown key "prot"`) {
+		t.Errorf("Expected the problems in the notice, got %q", m.notice)
+	}
+
+	m, _ = update(m, configReloadedMsg{err: errors.New("yaml: line 2: did not find expected key")})
+
+	if !strings.Contains(m.notice, "Could not reload the config") || len(m.config.Databases) != 1 {
+		t.Errorf("Expected to keep the previous config, got %q with %+v", m.notice, m.config.Databases)
+	}
+}
+
+// polls counts the config polls cmd leads to, running batches through.
+func polls(cmd tea.Cmd) int {
+	if cmd == nil {
+		return 0
+	}
+
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+
+	select {
+	case msg := <-done:
+		switch msg := msg.(type) {
+		case configPollMsg:
+			return 1
+		case tea.BatchMsg:
+			count := 0
+			for _, c := range msg {
+				count += polls(c)
+			}
+			return count
+		}
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	return 0
+}
+
+func TestSaveKeepsOnePollLoop(t *testing.T) {
+	interval := configPollInterval
+	configPollInterval = time.Millisecond
+	t.Cleanup(func() { configPollInterval = interval })
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	os.WriteFile(path, []byte("databases:\n  - name: local\n"), 0o600)
+
+	m := initialModel(&config.AppConfig{Path: path, Files: []string{path}})
+
+	for save := 0; save < 3; save++ {
+		var cmd tea.Cmd
+		m, cmd = update(m, database.SavedMsg{Name: "local", Path: path})
+
+		reloaded, ok := find[configReloadedMsg](cmd)
+		if !ok {
+			t.Fatalf("Expected the save to reload the config")
+		}
+
+		m, cmd = update(m, reloaded)
+		if count := polls(cmd); count != 0 {
+			t.Fatalf("Expected no new poll after save %d, got %d", save+1, count)
+		}
+	}
+
+	later := time.Now().Add(time.Minute)
+	os.Chtimes(path, later, later)
+
+	m, cmd := update(m, configPollMsg{stamps: statConfig(m.config.Files)})
+	reloaded, _ := find[configReloadedMsg](cmd)
+
+	if _, cmd = update(m, reloaded); polls(cmd) != 1 {
+		t.Errorf("Expected a polled reload to carry on the poll loop")
+	}
+}
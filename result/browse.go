@@ -0,0 +1,234 @@
+package result
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	db "gosuite/db"
+)
+
+const PageSize = 50
+
+// OpenTableMsg asks the results pane to browse a table.
+type OpenTableMsg struct {
+	Table string
+}
+
+func OpenTable(table string) tea.Cmd {
+	return func() tea.Msg {
+		return OpenTableMsg{Table: table}
+	}
+}
+
+// Browse pages through a table with keyset pagination on its primary key,
+// generating SQL the user can see and tweak in the query pane.
+type Browse struct {
+	Table      string
+	PrimaryKey []string
+	SortColumn string
+	SortDesc   bool
+	Where      string
+
+	ForeignKeys []db.ForeignKey
+
+	// pages holds the row each page starts after, nil for the first page.
+	// Tables without a primary key fall back to OFFSET.
+	pages []map[string]interface{}
+}
+
+func NewBrowse(table string, primaryKey []string) Browse {
+	return Browse{
+		Table:      table,
+		PrimaryKey: primaryKey,
+		pages:      []map[string]interface{}{nil},
+	}
+}
+
+func (b Browse) Page() int {
+	return len(b.pages)
+}
+
+func (b Browse) sortsByColumn() bool {
+	if b.SortColumn == "" {
+		return false
+	}
+	return !(len(b.PrimaryKey) == 1 && b.PrimaryKey[0] == b.SortColumn)
+}
+
+func (b Browse) keyCondition(after map[string]interface{}) string {
+	op := ">"
+	if b.SortDesc {
+		op = "<"
+	}
+
+	columns := make([]string, len(b.PrimaryKey))
+	values := make([]string, len(b.PrimaryKey))
+	for idx, column := range b.PrimaryKey {
+		columns[idx] = db.QuoteIdentifier(column)
+		values[idx] = db.QuoteValue(after[column])
+	}
+
+	keyCondition := fmt.Sprintf("(%s) %s (%s)", strings.Join(columns, ", "), op, strings.Join(values, ", "))
+
+	if !b.sortsByColumn() {
+		return keyCondition
+	}
+
+	// MySQL sorts NULLs first, so they come before every value going up
+	// and after every value going down.
+	column := db.QuoteIdentifier(b.SortColumn)
+	value := after[b.SortColumn]
+
+	switch {
+	case value == nil && !b.SortDesc:
+		return fmt.Sprintf("((%s IS NULL AND %s) OR %s IS NOT NULL)", column, keyCondition, column)
+	case value == nil:
+		return fmt.Sprintf("(%s IS NULL AND %s)", column, keyCondition)
+	case !b.SortDesc:
+		return fmt.Sprintf("(%s %s %s OR (%s = %s AND %s))",
+			column, op, db.QuoteValue(value), column, db.QuoteValue(value), keyCondition)
+	default:
+		return fmt.Sprintf("(%s %s %s OR (%s = %s AND %s) OR %s IS NULL)",
+			column, op, db.QuoteValue(value), column, db.QuoteValue(value), keyCondition, column)
+	}
+}
+
+func (b Browse) SQL() string {
+	conditions := make([]string, 0)
+
+	if strings.TrimSpace(b.Where) != "" {
+		conditions = append(conditions, "("+strings.TrimSpace(b.Where)+")")
+	}
+
+	after := b.pages[len(b.pages)-1]
+	if after != nil && len(b.PrimaryKey) > 0 {
+		conditions = append(conditions, b.keyCondition(after))
+	}
+
+	direction := "ASC"
+	if b.SortDesc {
+		direction = "DESC"
+	}
+
+	order := make([]string, 0)
+	if b.sortsByColumn() || (b.SortColumn != "" && len(b.PrimaryKey) == 0) {
+		order = append(order, db.QuoteIdentifier(b.SortColumn)+" "+direction)
+	}
+	for _, column := range b.PrimaryKey {
+		order = append(order, db.QuoteIdentifier(column)+" "+direction)
+	}
+
+	sql := "SELECT * FROM " + db.QuoteIdentifier(b.Table)
+
+	if len(conditions) > 0 {
+		sql += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	if len(order) > 0 {
+		sql += " ORDER BY " + strings.Join(order, ", ")
+	}
+
+	sql += fmt.Sprintf(" LIMIT %d", PageSize)
+
+	if len(b.PrimaryKey) == 0 && len(b.pages) > 1 {
+		sql += fmt.Sprintf(" OFFSET %d", (len(b.pages)-1)*PageSize)
+	}
+
+	return sql
+}
+
+// NextPage moves past last, the final row of the current page.
+func (b Browse) NextPage(last map[string]interface{}) Browse {
+	if last == nil {
+		last = map[string]interface{}{}
+	}
+	b.pages = append(append([]map[string]interface{}{}, b.pages...), last)
+	return b
+}
+
+func (b Browse) PreviousPage() Browse {
+	if len(b.pages) > 1 {
+		b.pages = b.pages[:len(b.pages)-1]
+	}
+	return b
+}
+
+// SortBy sorts on column, flipping the direction when it is already the
+// sort column. Changing the order starts again from the first page.
+func (b Browse) SortBy(column string) Browse {
+	if b.SortColumn == column || (b.SortColumn == "" && len(b.PrimaryKey) == 1 && b.PrimaryKey[0] == column) {
+		b.SortColumn = column
+		b.SortDesc = !b.SortDesc
+	} else {
+		b.SortColumn = column
+		b.SortDesc = false
+	}
+	b.pages = []map[string]interface{}{nil}
+	return b
+}
+
+func (b Browse) Filter(where string) Browse {
+	b.Where = where
+	b.pages = []map[string]interface{}{nil}
+	return b
+}
+
+// QuickFilter narrows the current filter to rows where column equals value.
+func (b Browse) QuickFilter(column string, value interface{}) Browse {
+	condition := db.QuoteIdentifier(column) + " = " + db.QuoteValue(value)
+	if value == nil {
+		condition = db.QuoteIdentifier(column) + " IS NULL"
+	}
+
+	if strings.TrimSpace(b.Where) != "" {
+		condition = "(" + strings.TrimSpace(b.Where) + ") AND " + condition
+	}
+
+	return b.Filter(condition)
+}
+
+// ReferenceFrom returns the foreign key of this table that column is part of.
+func (b Browse) ReferenceFrom(column string) (db.ForeignKey, bool) {
+	for _, fk := range b.ForeignKeys {
+		if fk.Table != b.Table {
+			continue
+		}
+		for _, fkColumn := range fk.Columns {
+			if fkColumn == column {
+				return fk, true
+			}
+		}
+	}
+
+	return db.ForeignKey{}, false
+}
+
+// ReferencedBy returns the foreign keys that point at this table.
+func (b Browse) ReferencedBy() []db.ForeignKey {
+	keys := make([]db.ForeignKey, 0)
+
+	for _, fk := range b.ForeignKeys {
+		if fk.ReferencedTable == b.Table {
+			keys = append(keys, fk)
+		}
+	}
+
+	return keys
+}
+
+// matchRow builds a condition matching columns to the values row has for
+// valueColumns, or false if any of them is NULL.
+func matchRow(columns []string, row map[string]interface{}, valueColumns []string) (string, bool) {
+	conditions := make([]string, len(columns))
+
+	for idx, column := range columns {
+		value, ok := row[valueColumns[idx]]
+		if !ok || value == nil {
+			return "", false
+		}
+		conditions[idx] = db.QuoteIdentifier(column) + " = " + db.QuoteValue(value)
+	}
+
+	return strings.Join(conditions, " AND "), true
+}
@@ -0,0 +1,88 @@
+package result
+
+import (
+	"testing"
+
+	db "gosuite/db"
+)
+
+func TestBrowseSQL(t *testing.T) {
+	browse := NewBrowse("posts", []string{"id"})
+
+	expected := "SELECT * FROM `posts` ORDER BY `id` ASC LIMIT 50"
+	if sql := browse.SQL(); sql != expected {
+		t.Errorf("Expected %q, got %q", expected, sql)
+	}
+
+	browse = browse.NextPage(map[string]interface{}{"id": int64(50)})
+
+	expected = "SELECT * FROM `posts` WHERE (`id`) > (50) ORDER BY `id` ASC LIMIT 50"
+	if sql := browse.SQL(); sql != expected {
+		t.Errorf("Expected %q, got %q", expected, sql)
+	}
+
+	browse = browse.PreviousPage().QuickFilter("author_id", int64(1))
+
+	expected = "SELECT * FROM `posts` WHERE (`author_id` = 1) ORDER BY `id` ASC LIMIT 50"
+	if sql := browse.SQL(); sql != expected {
+		t.Errorf("Expected %q, got %q", expected, sql)
+	}
+}
+
+func TestBrowseSortedKeyset(t *testing.T) {
+	browse := NewBrowse("posts", []string{"id"}).
+		SortBy("title").
+		SortBy("title").
+		NextPage(map[string]interface{}{"id": int64(7), "title": "It's"})
+
+	expected := "SELECT * FROM `posts` WHERE (`title` < 'It''s' OR (`title` = 'It''s' AND (`id`) < (7)) OR `title` IS NULL) " +
+		"ORDER BY `title` DESC, `id` DESC LIMIT 50"
+	if sql := browse.SQL(); sql != expected {
+		t.Errorf("Expected %q, got %q", expected, sql)
+	}
+}
+
+func TestBrowseWithoutPrimaryKey(t *testing.T) {
+	browse := NewBrowse("logs", nil).NextPage(nil).NextPage(nil)
+
+	expected := "SELECT * FROM `logs` LIMIT 50 OFFSET 100"
+	if sql := browse.SQL(); sql != expected {
+		t.Errorf("Expected %q, got %q", expected, sql)
+	}
+}
+
+func TestBrowseReferences(t *testing.T) {
+	browse := NewBrowse("posts", []string{"id"})
+	browse.ForeignKeys = []db.ForeignKey{
+		{Name: "posts_ibfk_1", Table: "posts", Columns: []string{"author_id"}, ReferencedTable: "authors", ReferencedColumns: []string{"id"}},
+		{Name: "comments_ibfk_1", Table: "comments", Columns: []string{"post_id"}, ReferencedTable: "posts", ReferencedColumns: []string{"id"}},
+	}
+
+	fk, ok := browse.ReferenceFrom("author_id")
+	if !ok || fk.ReferencedTable != "authors" {
+		t.Fatalf("Expected author_id to reference authors, got %v", fk)
+	}
+
+	if _, ok := browse.ReferenceFrom("title"); ok {
+		t.Errorf("Expected title not to be a foreign key")
+	}
+
+	row := map[string]interface{}{"id": int64(3), "author_id": int64(1)}
+
+	if where, _ := matchRow(fk.ReferencedColumns, row, fk.Columns); where != "`id` = 1" {
+		t.Errorf("Expected parent filter `id` = 1, got %q", where)
+	}
+
+	children := browse.ReferencedBy()
+	if len(children) != 1 || children[0].Table != "comments" {
+		t.Fatalf("Expected comments to reference posts, got %v", children)
+	}
+
+	if where, _ := matchRow(children[0].Columns, row, children[0].ReferencedColumns); where != "`post_id` = 3" {
+		t.Errorf("Expected child filter `post_id` = 3, got %q", where)
+	}
+
+	if _, ok := matchRow(fk.ReferencedColumns, map[string]interface{}{"author_id": nil}, fk.Columns); ok {
+		t.Errorf("Expected a NULL foreign key not to match")
+	}
+}
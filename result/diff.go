@@ -0,0 +1,170 @@
+package result
+
+import (
+	"fmt"
+	"strings"
+
+	db "gosuite/db"
+)
+
+type Change int
+
+const (
+	Unchanged Change = iota
+	Added
+	Removed
+	Changed
+)
+
+type RowDiff struct {
+	Change Change
+	Before map[string]interface{}
+	After  map[string]interface{}
+
+	// ChangedColumns are the cells that differ in a changed row.
+	ChangedColumns map[string]bool
+}
+
+// Row is the row as it is now, or as it was for removed rows.
+func (r RowDiff) Row() map[string]interface{} {
+	if r.Change == Removed {
+		return r.Before
+	}
+	return r.After
+}
+
+type Diff struct {
+	Key     []string
+	Columns []string
+	Rows    []RowDiff
+
+	Added     int
+	Removed   int
+	Changed   int
+	Unchanged int
+}
+
+func cell(value interface{}) string {
+	if value == nil {
+		return "NULL"
+	}
+	return fmt.Sprint(value)
+}
+
+func rowKey(row map[string]interface{}, key []string) string {
+	values := make([]string, len(key))
+	for idx, column := range key {
+		values[idx] = cell(row[column])
+	}
+	return strings.Join(values, "\x00")
+}
+
+func hasColumns(result *db.ExecuteResult, columns []string) bool {
+	for _, column := range columns {
+		found := false
+		for _, c := range result.Columns {
+			if c == column {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func indexRows(result *db.ExecuteResult, key []string) (map[string]map[string]interface{}, error) {
+	rows := make(map[string]map[string]interface{}, len(result.Rows))
+
+	for _, row := range result.Rows {
+		k := rowKey(row, key)
+		if _, ok := rows[k]; ok {
+			return nil, fmt.Errorf("%s is not unique in %s", strings.Join(key, ", "), result.Query)
+		}
+		rows[k] = row
+	}
+
+	return rows, nil
+}
+
+// DiffResults matches the rows of two result sets on the key columns.
+// Rows come in the order of after, followed by the removed rows.
+func DiffResults(before *db.ExecuteResult, after *db.ExecuteResult, key []string) (Diff, error) {
+	diff := Diff{Key: key}
+
+	if len(key) == 0 {
+		return diff, fmt.Errorf("no key column to match rows on")
+	}
+	if !hasColumns(before, key) || !hasColumns(after, key) {
+		return diff, fmt.Errorf("both results need the key column %s", strings.Join(key, ", "))
+	}
+
+	beforeRows, err := indexRows(before, key)
+	if err != nil {
+		return diff, err
+	}
+	if _, err := indexRows(after, key); err != nil {
+		return diff, err
+	}
+
+	diff.Columns = append(diff.Columns, after.Columns...)
+	for _, column := range before.Columns {
+		if !hasColumns(after, []string{column}) {
+			diff.Columns = append(diff.Columns, column)
+		}
+	}
+
+	seen := make(map[string]bool, len(after.Rows))
+
+	for _, row := range after.Rows {
+		k := rowKey(row, key)
+		seen[k] = true
+
+		previous, ok := beforeRows[k]
+		if !ok {
+			diff.Rows = append(diff.Rows, RowDiff{Change: Added, After: row})
+			diff.Added++
+			continue
+		}
+
+		changed := make(map[string]bool)
+		for _, column := range diff.Columns {
+			_, wasSet := previous[column]
+			_, isSet := row[column]
+			if wasSet != isSet || cell(previous[column]) != cell(row[column]) {
+				changed[column] = true
+			}
+		}
+
+		if len(changed) == 0 {
+			diff.Rows = append(diff.Rows, RowDiff{Change: Unchanged, Before: previous, After: row})
+			diff.Unchanged++
+			continue
+		}
+
+		diff.Rows = append(diff.Rows, RowDiff{Change: Changed, Before: previous, After: row, ChangedColumns: changed})
+		diff.Changed++
+	}
+
+	for _, row := range before.Rows {
+		if !seen[rowKey(row, key)] {
+			diff.Rows = append(diff.Rows, RowDiff{Change: Removed, Before: row})
+			diff.Removed++
+		}
+	}
+
+	return diff, nil
+}
+
+// Differences drops the unchanged rows.
+func (d Diff) Differences() []RowDiff {
+	rows := make([]RowDiff, 0, len(d.Rows))
+	for _, row := range d.Rows {
+		if row.Change != Unchanged {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
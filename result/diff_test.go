@@ -0,0 +1,121 @@
+package result
+
+import (
+	"testing"
+
+	db "gosuite/db"
+)
+
+func TestDiffResults(t *testing.T) {
+	before := &db.ExecuteResult{
+		Query:   "SELECT * FROM authors",
+		Columns: []string{"id", "name", "country"},
+		Rows: []map[string]interface{}{
+			{"id": "1", "name": "Ann", "country": "UK"},
+			{"id": "2", "name": "Bob", "country": "US"},
+			{"id": "3", "name": "Cat", "country": nil},
+		},
+	}
+	after := &db.ExecuteResult{
+		Query:   "SELECT * FROM authors",
+		Columns: []string{"id", "name", "country"},
+		Rows: []map[string]interface{}{
+			{"id": "1", "name": "Ann", "country": "UK"},
+			{"id": "3", "name": "Cat", "country": "FR"},
+			{"id": "4", "name": "Dan", "country": "DE"},
+		},
+	}
+
+	diff, err := DiffResults(before, after, []string{"id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff.Added != 1 || diff.Removed != 1 || diff.Changed != 1 || diff.Unchanged != 1 {
+		t.Errorf("Expected 1 added, removed, changed and unchanged, got %+v", diff)
+	}
+
+	expected := []Change{Unchanged, Changed, Added, Removed}
+	for idx, row := range diff.Rows {
+		if row.Change != expected[idx] {
+			t.Errorf("Expected %v at %d, got %v", expected[idx], idx, row.Change)
+		}
+	}
+
+	changed := diff.Rows[1].ChangedColumns
+	if len(changed) != 1 || !changed["country"] {
+		t.Errorf("Expected only country to change, got %v", changed)
+	}
+
+	if removed := diff.Rows[3].Row()["name"]; removed != "Bob" {
+		t.Errorf("Expected Bob to be removed, got %v", removed)
+	}
+
+	if len(diff.Differences()) != 3 {
+		t.Errorf("Expected 3 differences, got %d", len(diff.Differences()))
+	}
+}
+
+func TestDiffResultsNewColumns(t *testing.T) {
+	before := &db.ExecuteResult{Columns: []string{"id", "old"}, Rows: []map[string]interface{}{{"id": 1, "old": "x"}}}
+	after := &db.ExecuteResult{Columns: []string{"id", "new"}, Rows: []map[string]interface{}{{"id": 1, "new": "y"}}}
+
+	diff, err := DiffResults(before, after, []string{"id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(diff.Columns) != 3 || diff.Columns[2] != "old" {
+		t.Errorf("Expected id, new, old, got %v", diff.Columns)
+	}
+	if changed := diff.Rows[0].ChangedColumns; !changed["old"] || !changed["new"] {
+		t.Errorf("Expected both columns to change, got %v", changed)
+	}
+}
+
+func TestDiffResultsKeyErrors(t *testing.T) {
+	result := &db.ExecuteResult{Columns: []string{"id"}, Rows: []map[string]interface{}{{"id": 1}, {"id": 1}}}
+
+	if _, err := DiffResults(result, result, []string{"id"}); err == nil {
+		t.Errorf("Expected an error for a key that isn't unique")
+	}
+	if _, err := DiffResults(result, result, []string{"name"}); err == nil {
+		t.Errorf("Expected an error for a missing key column")
+	}
+}
+
+func TestDiffTabs(t *testing.T) {
+	m := InitModel()
+
+	for _, name := range []string{"Ann", "Anne"} {
+		m, _ = m.Update(db.ExecuteResult{
+			Query:   "SELECT id, name FROM authors",
+			Columns: []string{"name", "id"},
+			Rows:    []map[string]interface{}{{"id": 1, "name": name}, {"id": 2, "name": "Bob"}},
+		}, true, nil)
+	}
+	m = run(m, "SELECT 1")
+
+	// Without a mark the last run of the same query is used.
+	m = press(m, "[")
+	m = press(m, "d")
+
+	if m.diff == nil || m.diff.err != nil {
+		t.Fatalf("Expected a diff, got %v", m.diff)
+	}
+	if m.diff.diff.Key[0] != "id" || m.diff.diff.Changed != 1 || m.diff.diff.Unchanged != 1 {
+		t.Errorf("Expected one changed row keyed on id, got %+v", m.diff.diff)
+	}
+
+	m = press(m, "c")
+
+	if m.diff.diff.Key[0] != "name" || m.diff.diff.Added != 1 || m.diff.diff.Removed != 1 {
+		t.Errorf("Expected Anne added and Ann removed keyed on name, got %+v", m.diff.diff)
+	}
+
+	m = press(m, "d")
+
+	if m.diff != nil {
+		t.Errorf("Expected the diff to close")
+	}
+}
@@ -0,0 +1,271 @@
+package result
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	design "gosuite/design"
+	keymap "gosuite/keymap"
+	theme "gosuite/theme"
+)
+
+// diffView compares the result sets of two tabs.
+type diffView struct {
+	before Tab
+	after  Tab
+
+	keys     [][]string
+	keyIndex int
+
+	diff   Diff
+	err    error
+	cursor int
+}
+
+// keyCandidates lists the keys to match rows on, the primary key of a
+// browsed table first, then every column both results share with id
+// ahead of the rest.
+func keyCandidates(before Tab, after Tab) [][]string {
+	candidates := make([][]string, 0)
+
+	for _, tab := range []Tab{after, before} {
+		if tab.browse == nil || len(tab.browse.PrimaryKey) == 0 {
+			continue
+		}
+		if hasColumns(before.result, tab.browse.PrimaryKey) && hasColumns(after.result, tab.browse.PrimaryKey) {
+			candidates = append(candidates, tab.browse.PrimaryKey)
+			break
+		}
+	}
+
+	columns := make([]string, 0, len(after.result.Columns))
+	for _, column := range after.result.Columns {
+		if !hasColumns(before.result, []string{column}) {
+			continue
+		}
+		if len(candidates) > 0 && len(candidates[0]) == 1 && candidates[0][0] == column {
+			continue
+		}
+		if strings.EqualFold(column, "id") {
+			columns = append([]string{column}, columns...)
+		} else {
+			columns = append(columns, column)
+		}
+	}
+
+	for _, column := range columns {
+		candidates = append(candidates, []string{column})
+	}
+
+	return candidates
+}
+
+func newDiffView(before Tab, after Tab) diffView {
+	if after.At.Before(before.At) {
+		before, after = after, before
+	}
+
+	d := diffView{before: before, after: after, keys: keyCandidates(before, after)}
+
+	return d.compute()
+}
+
+func (d diffView) compute() diffView {
+	d.cursor = 0
+
+	if len(d.keys) == 0 {
+		d.err = fmt.Errorf("the results have no columns in common")
+		return d
+	}
+
+	d.diff, d.err = DiffResults(d.before.result, d.after.result, d.keys[d.keyIndex])
+
+	return d
+}
+
+// diffTarget picks the tab to compare the current one with: the marked
+// tab, else the last run of the same query, else the tab before.
+func (m Model) diffTarget() (int, bool) {
+	if m.marked != nil {
+		for idx, tab := range m.tabs {
+			if tab.result == m.marked && idx != m.current {
+				return idx, true
+			}
+		}
+	}
+
+	for idx := m.current - 1; idx >= 0; idx-- {
+		if m.tabs[idx].result != nil && m.tabs[idx].Query == m.tabs[m.current].Query {
+			return idx, true
+		}
+	}
+
+	for _, idx := range []int{m.current - 1, m.current + 1} {
+		if idx >= 0 && idx < len(m.tabs) && m.tabs[idx].result != nil {
+			return idx, true
+		}
+	}
+
+	return 0, false
+}
+
+func (m Model) openDiff() Model {
+	m = m.stash()
+
+	idx, ok := m.diffTarget()
+	if !ok {
+		m.notice = "Run another query or mark a tab to compare against"
+		return m
+	}
+
+	diff := newDiffView(m.tabs[idx], m.tabs[m.current])
+	m.diff = &diff
+
+	return m
+}
+
+func (m Model) toggleMark() Model {
+	if m.marked == m.result {
+		m.marked = nil
+	} else {
+		m.marked = m.result
+	}
+	return m
+}
+
+func (m Model) updateDiff(msg tea.KeyMsg) Model {
+	keys := keymap.Keys.Result
+	diff := *m.diff
+
+	switch {
+	case key.Matches(msg, keys.Diff, keymap.Keys.Dialog.Cancel):
+		m.diff = nil
+		return m
+	case key.Matches(msg, keys.Up):
+		diff = diff.moveCursor(-1)
+	case key.Matches(msg, keys.Down):
+		diff = diff.moveCursor(1)
+	case key.Matches(msg, keys.DiffKey):
+		if len(diff.keys) > 0 {
+			diff.keyIndex = (diff.keyIndex + 1) % len(diff.keys)
+			diff = diff.compute()
+		}
+	}
+
+	m.diff = &diff
+
+	return m
+}
+
+func (d diffView) moveCursor(delta int) diffView {
+	d.cursor = min(max(d.cursor+delta, 0), max(len(d.diff.Differences())-1, 0))
+	return d
+}
+
+func changeStyle(change Change) lipgloss.Style {
+	switch change {
+	case Added:
+		return theme.Style(theme.Current.Added)
+	case Removed:
+		return theme.Style(theme.Current.Removed)
+	case Changed:
+		return theme.Style(theme.Current.Changed)
+	}
+	return lipgloss.NewStyle()
+}
+
+var changeSigns = map[Change]string{Unchanged: " ", Added: "+", Removed: "-", Changed: "~"}
+
+func (d diffView) renderRow(row RowDiff, selected bool, width int) string {
+	sign := changeStyle(row.Change).Bold(true).Render(changeSigns[row.Change] + " ")
+	if selected {
+		sign = theme.Selection().Render(changeSigns[row.Change] + " ")
+	}
+
+	content := sign
+	values := row.Row()
+
+	for _, column := range d.diff.Columns {
+		cellWidth := getWidthFromColumn(column)
+		style := changeStyle(row.Change).Padding(0, 1).Width(cellWidth)
+
+		if row.ChangedColumns[column] {
+			style = style.Foreground(theme.Current.SelectionForeground).Background(theme.Current.Changed)
+		}
+
+		content += style.Render(truncateString(cell(values[column]), cellWidth-2))
+	}
+
+	return lipgloss.NewStyle().MaxWidth(width).Render(content)
+}
+
+// renderDetail spells out the changes of the row under the cursor.
+func (d diffView) renderDetail(row RowDiff) string {
+	if row.Change != Changed {
+		return ""
+	}
+
+	changes := make([]string, 0, len(row.ChangedColumns))
+	for _, column := range d.diff.Columns {
+		if row.ChangedColumns[column] {
+			changes = append(changes, fmt.Sprintf("%s: %s → %s", column, cell(row.Before[column]), cell(row.After[column])))
+		}
+	}
+
+	return strings.Join(changes, " · ")
+}
+
+func (d diffView) View(width int, height int) string {
+	muted := theme.Style(theme.Current.Muted)
+
+	lines := []string{muted.Render(fmt.Sprintf("Diff %s → %s", d.before.Label(), d.after.Label()))}
+
+	if d.err != nil {
+		lines = append(lines, theme.Style(theme.Current.Error).Render(d.err.Error()))
+		if len(d.keys) > 1 {
+			lines = append(lines, muted.Render(keymap.Keys.Result.DiffKey.Help().Key+" to match rows on another column"))
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	lines = append(lines, fmt.Sprintf(
+		"%s  %s  %s  %s  %s",
+		muted.Render("on "+strings.Join(d.diff.Key, ", ")),
+		changeStyle(Added).Render(fmt.Sprintf("+%d added", d.diff.Added)),
+		changeStyle(Removed).Render(fmt.Sprintf("-%d removed", d.diff.Removed)),
+		changeStyle(Changed).Render(fmt.Sprintf("~%d changed", d.diff.Changed)),
+		muted.Render(fmt.Sprintf("%d unchanged", d.diff.Unchanged)),
+	))
+
+	rows := d.diff.Differences()
+	if len(rows) == 0 {
+		lines = append(lines, "", "The results are the same.")
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	header := make([]string, 0, len(d.diff.Columns)+1)
+	header = append(header, "  ")
+	for _, column := range d.diff.Columns {
+		header = append(header, lipgloss.NewStyle().
+			Padding(0, 1).
+			Background(theme.Current.Header).
+			Width(getWidthFromColumn(column)).
+			Render(column))
+	}
+	lines = append(lines, lipgloss.NewStyle().MaxWidth(width).Render(lipgloss.JoinHorizontal(lipgloss.Left, header...)))
+
+	detail := d.renderDetail(rows[d.cursor])
+
+	visible := height - len(lines) - 1
+	start := design.ScrollStart(d.cursor, visible)
+
+	for idx := start; idx < len(rows) && idx-start < visible; idx++ {
+		lines = append(lines, d.renderRow(rows[idx], idx == d.cursor, width))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, append(lines, muted.Render(detail))...)
+}
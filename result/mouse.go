@@ -0,0 +1,67 @@
+package result
+
+import (
+	design "gosuite/design"
+)
+
+// columnAt returns the column drawn at x, counting from the left edge of
+// the grid.
+func columnAt(columns []string, x int) (int, bool) {
+	left := 0
+
+	for idx, column := range columns {
+		left += getWidthFromColumn(column)
+		if x < left {
+			return idx, true
+		}
+	}
+
+	return 0, false
+}
+
+func (m Model) updateMouse(msg design.MouseMsg) Model {
+	if scroll := msg.Scroll(); scroll != 0 {
+		m.cursor.Row = min(max(m.cursor.Row+scroll, 0), max(len(m.view.order)-1, 0))
+		return m
+	}
+
+	if !msg.Clicked() {
+		return m
+	}
+
+	x, y, ok := msg.Content()
+	if !ok {
+		return m
+	}
+
+	// The tab bar and the column headers come before the rows, clicking a
+	// header sorts by it.
+	if y == 0 {
+		if idx, ok := m.tabAt(x); ok {
+			return m.switchTab(idx)
+		}
+		return m
+	}
+	if y == 1 {
+		if column, ok := columnAt(m.result.Columns, x); ok {
+			return m.sortRows(m.result.Columns[column])
+		}
+		return m
+	}
+
+	_, contentHeight := design.ContentSize(msg.Width, msg.Height)
+	visible := visibleRows(contentHeight, m.renderFooter())
+
+	row := design.ScrollStart(m.cursor.Row, visible) + y - 2
+	if y-2 >= visible || row >= len(m.view.order) {
+		return m
+	}
+
+	column, ok := columnAt(m.result.Columns, x)
+	if !ok {
+		return m
+	}
+
+	m.cursor = Cursor{Row: row, Column: column}
+	return m
+}
@@ -0,0 +1,157 @@
+package result
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	db "gosuite/db"
+	keymap "gosuite/keymap"
+	theme "gosuite/theme"
+)
+
+// crumb is a table browse the user navigated away from along a foreign key.
+type crumb struct {
+	browse Browse
+	cursor Cursor
+}
+
+type tableOpenedMsg struct {
+	keys  db.TableKeys
+	where string
+	from  *crumb
+	err   error
+}
+
+// openTable looks up the keys of table, from is the browse it was opened
+// along a foreign key from, if any.
+func openTable(conn *sql.DB, table string, where string, from *crumb) tea.Cmd {
+	return func() tea.Msg {
+		keys, err := db.GetTableKeys(conn, table)
+		return tableOpenedMsg{keys: keys, where: where, from: from, err: err}
+	}
+}
+
+func (m Model) openRelated(conn *db.Connection, table string, where string) (Model, tea.Cmd) {
+	if conn == nil || (*conn).GetConnection() == nil {
+		return m, nil
+	}
+
+	// The crumb is only pushed once the related rows arrive, so a failed
+	// open leaves the breadcrumb as it was.
+	return m, openTable((*conn).GetConnection(), table, where, &crumb{browse: *m.browse, cursor: m.cursor})
+}
+
+// followReference jumps to the row the foreign key under the cursor points at.
+func (m Model) followReference(conn *db.Connection) (Model, tea.Cmd) {
+	column := m.currentColumn()
+
+	fk, ok := m.browse.ReferenceFrom(column)
+	if !ok {
+		m.notice = fmt.Sprintf("%s is not a foreign key", column)
+		return m, nil
+	}
+
+	where, ok := matchRow(fk.ReferencedColumns, m.currentRow(), fk.Columns)
+	if !ok {
+		m.notice = fmt.Sprintf("%s is NULL", column)
+		return m, nil
+	}
+
+	return m.openRelated(conn, fk.ReferencedTable, where)
+}
+
+// listReferences shows the rows of other tables that point at the current
+// row, asking which table to follow when there is more than one.
+func (m Model) listReferences(conn *db.Connection) (Model, tea.Cmd) {
+	keys := m.browse.ReferencedBy()
+
+	switch len(keys) {
+	case 0:
+		m.notice = fmt.Sprintf("No tables reference %s", m.browse.Table)
+		return m, nil
+	case 1:
+		return m.followReferencedBy(conn, keys[0])
+	}
+
+	m.picker = keys
+	m.pickerIndex = 0
+
+	return m, nil
+}
+
+func (m Model) followReferencedBy(conn *db.Connection, fk db.ForeignKey) (Model, tea.Cmd) {
+	where, ok := matchRow(fk.Columns, m.currentRow(), fk.ReferencedColumns)
+	if !ok {
+		m.notice = fmt.Sprintf("%s has no value for %s", m.browse.Table, strings.Join(fk.ReferencedColumns, ", "))
+		return m, nil
+	}
+
+	return m.openRelated(conn, fk.Table, where)
+}
+
+func (m Model) back() (Model, tea.Cmd) {
+	if len(m.history) == 0 {
+		return m, nil
+	}
+
+	previous := m.history[len(m.history)-1]
+	m.history = m.history[:len(m.history)-1]
+	m.restore = &previous.cursor
+
+	return m.browseTo(previous.browse)
+}
+
+func (m Model) updatePicker(msg tea.KeyMsg, conn *db.Connection) (Model, tea.Cmd) {
+	keys := keymap.Keys.Result
+
+	switch {
+	case key.Matches(msg, keys.Up):
+		if m.pickerIndex > 0 {
+			m.pickerIndex--
+		}
+	case key.Matches(msg, keys.Down):
+		if m.pickerIndex < len(m.picker)-1 {
+			m.pickerIndex++
+		}
+	case key.Matches(msg, keymap.Keys.Dialog.Cancel):
+		m.picker = nil
+	case key.Matches(msg, keymap.Keys.Dialog.Submit):
+		fk := m.picker[m.pickerIndex]
+		m.picker = nil
+		return m.followReferencedBy(conn, fk)
+	}
+
+	return m, nil
+}
+
+func (m Model) renderPicker() string {
+	lines := []string{fmt.Sprintf("Rows referencing this %s:", m.browse.Table), ""}
+
+	for idx, fk := range m.picker {
+		line := "  " + fk.String()
+		if idx == m.pickerIndex {
+			line = theme.Selection().Render("> " + fk.String())
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "", theme.Style(theme.Current.Muted).Render(keymap.Keys.Dialog.Submit.Help().Key+" to open, "+keymap.Keys.Dialog.Cancel.Help().Key+" to cancel"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func (m Model) renderBreadcrumb() string {
+	parts := make([]string, 0, len(m.history)+1)
+
+	for _, crumb := range m.history {
+		parts = append(parts, crumb.browse.Table)
+	}
+	parts = append(parts, m.browse.Table)
+
+	return strings.Join(parts, " › ")
+}
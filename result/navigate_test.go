@@ -0,0 +1,41 @@
+package result
+
+import (
+	"fmt"
+	"testing"
+
+	db "gosuite/db"
+)
+
+func TestBreadcrumbPushedOnceRelatedRowsArrive(t *testing.T) {
+	posts := NewBrowse("posts", []string{"id"})
+	m := InitModel().openTab(posts.SQL())
+	m.browse = &posts
+	m = run(m, posts.SQL())
+	from := &crumb{browse: posts, cursor: m.cursor}
+
+	m, _ = m.Update(tableOpenedMsg{keys: db.TableKeys{Table: "authors"}, from: from, err: fmt.Errorf("denied")}, true, nil)
+
+	if len(m.history) != 0 || m.browse.Table != "posts" {
+		t.Errorf("Expected no crumb after a failed open, got %d on %s", len(m.history), m.browse.Table)
+	}
+
+	m, _ = m.Update(tableOpenedMsg{keys: db.TableKeys{Table: "authors", PrimaryKey: []string{"id"}}, where: "`id` = 1", from: from}, true, nil)
+
+	if len(m.history) != 0 {
+		t.Errorf("Expected no crumb before the rows arrive, got %d", len(m.history))
+	}
+
+	m, _ = m.Update(db.ExecuteError{Query: m.browse.SQL(), Err: fmt.Errorf("timeout")}, true, nil)
+
+	if len(m.history) != 0 || m.browse.Table != "posts" {
+		t.Errorf("Expected to stay on posts after a failed query, got %d crumbs on %s", len(m.history), m.browse.Table)
+	}
+
+	m, _ = m.Update(tableOpenedMsg{keys: db.TableKeys{Table: "authors", PrimaryKey: []string{"id"}}, where: "`id` = 1", from: from}, true, nil)
+	m = run(m, m.browse.SQL())
+
+	if len(m.history) != 1 || m.history[0].browse.Table != "posts" || m.browse.Table != "authors" {
+		t.Errorf("Expected posts › authors, got %s", m.renderBreadcrumb())
+	}
+}
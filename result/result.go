@@ -2,12 +2,18 @@ package result
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	db "gosuite/db"
 	design "gosuite/design"
+	keymap "gosuite/keymap"
+	theme "gosuite/theme"
 )
 
 type Cursor struct {
@@ -17,12 +23,55 @@ type Cursor struct {
 
 type Model struct {
 	result *db.ExecuteResult
+	err    *db.ExecuteError
 	cursor Cursor
+
+	browse    *Browse
+	filter    textinput.Model
+	filtering bool
+
+	// view is the order rows are shown in, the cursor row indexes into it.
+	view         rowView
+	search       textinput.Model
+	searching    bool
+	term         string
+	columnFilter textinput.Model
+	filterColumn string
+
+	history     []crumb
+	pending     *crumb
+	picker      []db.ForeignKey
+	pickerIndex int
+	restore     *Cursor
+	notice      string
+
+	// tabs keeps every result set, the one shown is tabs[current] but
+	// lives in the fields above until it's stashed.
+	tabs    []Tab
+	current int
+	marked  *db.ExecuteResult
+	diff    *diffView
+
+	// picked are the rows chosen in gosuite pick, by index into the result.
+	picked map[int]bool
 }
 
 func InitModel() Model {
+	filter := textinput.New()
+	filter.Prompt = "WHERE "
+	filter.Placeholder = "author_id = 1 AND title LIKE '%go%'"
+
+	search := textinput.New()
+	search.Prompt = "/"
+
+	columnFilter := textinput.New()
+	columnFilter.Placeholder = "> 10, = draft, ~ text, is null"
+
 	return Model{
-		cursor: Cursor{0, 0},
+		cursor:       Cursor{0, 0},
+		filter:       filter,
+		search:       search,
+		columnFilter: columnFilter,
 	}
 }
 
@@ -30,33 +79,284 @@ func (m Model) Init() tea.Cmd {
 	return nil
 }
 
-func (m Model) Update(msg tea.Msg, active bool) (Model, tea.Cmd) {
+// Filtering reports whether one of the filter or search bars has focus.
+func (m Model) Filtering() bool {
+	return m.filtering || m.searching || m.filterColumn != ""
+}
+
+// SetPicked marks rows, by index into the result, as chosen.
+func (m Model) SetPicked(rows map[int]bool) Model {
+	m.picked = rows
+	return m
+}
+
+// Picking reports whether the menu of tables referencing the row is open.
+func (m Model) Picking() bool {
+	return m.picker != nil
+}
+
+func (m Model) Cursor() Cursor {
+	return m.cursor
+}
+
+func (m Model) Result() *db.ExecuteResult {
+	return m.result
+}
+
+// RowIndex returns the index in Result().Rows of the row under the cursor,
+// which differs from the cursor row once the rows are sorted or filtered.
+func (m Model) RowIndex() (int, bool) {
+	if m.result == nil || m.cursor.Row >= len(m.view.order) {
+		return 0, false
+	}
+	return m.view.order[m.cursor.Row], true
+}
+
+func (m Model) currentColumn() string {
+	if m.result == nil || m.cursor.Column >= len(m.result.Columns) {
+		return ""
+	}
+	return m.result.Columns[m.cursor.Column]
+}
+
+// rows are the rows left after filtering, in the order they're shown.
+func (m Model) rows() []map[string]interface{} {
+	if m.result == nil {
+		return nil
+	}
+
+	rows := make([]map[string]interface{}, len(m.view.order))
+	for idx, row := range m.view.order {
+		rows[idx] = m.result.Rows[row]
+	}
+
+	return rows
+}
+
+// pickedRows is whether each row, in the order shown, is picked.
+func (m Model) pickedRows() []bool {
+	if len(m.picked) == 0 {
+		return nil
+	}
+
+	picked := make([]bool, len(m.view.order))
+	for idx, row := range m.view.order {
+		picked[idx] = m.picked[row]
+	}
+
+	return picked
+}
+
+func (m Model) currentRow() map[string]interface{} {
+	if m.result == nil || m.cursor.Row >= len(m.view.order) {
+		return nil
+	}
+	return m.result.Rows[m.view.order[m.cursor.Row]]
+}
+
+func (m Model) browseTo(browse Browse) (Model, tea.Cmd) {
+	m.browse = &browse
+	return m, db.RequestExecute(browse.SQL())
+}
+
+func (m Model) updateFilter(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keymap.Keys.Dialog.Cancel):
+		m.filtering = false
+		m.filter.Blur()
+		return m, nil
+	case key.Matches(msg, keymap.Keys.Dialog.Submit):
+		m.filtering = false
+		m.filter.Blur()
+		return m.browseTo(m.browse.Filter(m.filter.Value()))
+	}
+
+	var cmd tea.Cmd
+	m.filter, cmd = m.filter.Update(msg)
+
+	return m, cmd
+}
+
+func (m Model) updateBrowse(msg tea.KeyMsg, conn *db.Connection) (Model, tea.Cmd) {
+	keys := keymap.Keys.Result
+
+	switch {
+	case key.Matches(msg, keys.Sort):
+		if column := m.currentColumn(); column != "" {
+			return m.browseTo(m.browse.SortBy(column))
+		}
+	case key.Matches(msg, keys.Filter):
+		m.filtering = true
+		m.filter.SetValue(m.browse.Where)
+		m.filter.CursorEnd()
+		return m, m.filter.Focus()
+	case key.Matches(msg, keys.QuickFilter):
+		if column := m.currentColumn(); column != "" {
+			return m.browseTo(m.browse.QuickFilter(column, m.currentRow()[column]))
+		}
+	case key.Matches(msg, keys.NextPage):
+		if len(m.result.Rows) == PageSize {
+			return m.browseTo(m.browse.NextPage(m.result.Rows[len(m.result.Rows)-1]))
+		}
+	case key.Matches(msg, keys.PrevPage):
+		if m.browse.Page() > 1 {
+			return m.browseTo(m.browse.PreviousPage())
+		}
+	case key.Matches(msg, keys.FollowReference):
+		return m.followReference(conn)
+	case key.Matches(msg, keys.ReferencedBy):
+		return m.listReferences(conn)
+	case key.Matches(msg, keys.Back):
+		return m.back()
+	}
+
+	return m, nil
+}
+
+func (m Model) Update(msg tea.Msg, active bool, conn *db.Connection) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case OpenTableMsg:
+		if conn != nil && (*conn).GetConnection() != nil {
+			m = m.openTab("SELECT * FROM " + db.QuoteIdentifier(msg.Table))
+			return m, openTable((*conn).GetConnection(), msg.Table, "", nil)
+		}
+	case tableOpenedMsg:
+		if msg.err != nil {
+			m.err = &db.ExecuteError{Query: "SELECT * FROM " + msg.keys.Table, Err: msg.err}
+			return m, nil
+		}
+		browse := NewBrowse(msg.keys.Table, msg.keys.PrimaryKey)
+		browse.ForeignKeys = msg.keys.ForeignKeys
+		m.pending = msg.from
+		return m.browseTo(browse.Filter(msg.where))
 	case db.ExecuteResult:
+		if m.browse == nil || m.browse.SQL() != msg.Query || m.current >= len(m.tabs) {
+			// A query run by hand gets a tab of its own.
+			m = m.openTab(msg.Query)
+		}
+		if m.pending != nil {
+			m.history = append(m.history, *m.pending)
+			m.pending = nil
+		}
 		m.result = &msg
+		m.view = m.view.apply(m.result)
+		m.err = nil
+		m.notice = ""
+		m.cursor.Row = 0
+		if m.cursor.Column >= len(msg.Columns) {
+			m.cursor.Column = 0
+		}
+		if m.restore != nil {
+			m.cursor = *m.restore
+			m.restore = nil
+			if m.cursor.Row >= len(m.view.order) {
+				m.cursor.Row = 0
+			}
+		}
+
+		m = m.stash()
+		m.tabs[m.current].Query = msg.Query
+		m.tabs[m.current].At = time.Now()
+	case db.ExecuteError:
+		m.err = &msg
+		if m.pending != nil {
+			// Stay on the table the related one was opened from.
+			m.browse = &m.pending.browse
+			m.pending = nil
+		}
+	case design.MouseMsg:
+		if m.diff != nil {
+			diff := m.diff.moveCursor(msg.Scroll())
+			m.diff = &diff
+			return m, nil
+		}
+		if m.result == nil || m.Filtering() || m.picker != nil {
+			return m, nil
+		}
+		return m.updateMouse(msg), nil
 	case tea.KeyMsg:
-		if !active {
+		if !active || m.result == nil {
 			return m, nil
 		}
 
-		switch msg.String() {
-		case "up":
+		if m.filtering {
+			return m.updateFilter(msg)
+		}
+
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+
+		if m.filterColumn != "" {
+			return m.updateColumnFilter(msg)
+		}
+
+		if m.picker != nil {
+			return m.updatePicker(msg, conn)
+		}
+
+		if m.diff != nil {
+			return m.updateDiff(msg), nil
+		}
+
+		m.notice = ""
+
+		keys := keymap.Keys.Result
+
+		switch {
+		case key.Matches(msg, keys.Up):
 			if m.cursor.Row > 0 {
 				m.cursor.Row--
 			}
-		case "down":
-			if m.cursor.Row < len(m.result.Rows)-1 {
+		case key.Matches(msg, keys.Down):
+			if m.cursor.Row < len(m.view.order)-1 {
 				m.cursor.Row++
 			}
-		case "left":
+		case key.Matches(msg, keys.Left):
 			if m.cursor.Column > 0 {
 				m.cursor.Column--
 			}
-		case "right":
+		case key.Matches(msg, keys.Right):
 			if m.cursor.Column < len(m.result.Columns)-1 {
 				m.cursor.Column++
 			}
-
+		case key.Matches(msg, keys.NextTab):
+			return m.switchTab((m.current + 1) % len(m.tabs)), nil
+		case key.Matches(msg, keys.PrevTab):
+			return m.switchTab((m.current + len(m.tabs) - 1) % len(m.tabs)), nil
+		case key.Matches(msg, keys.PinTab):
+			return m.togglePin(), nil
+		case key.Matches(msg, keys.CloseTab):
+			return m.closeTab(), nil
+		case key.Matches(msg, keys.MarkTab):
+			return m.toggleMark(), nil
+		case key.Matches(msg, keys.Diff):
+			return m.openDiff(), nil
+		case key.Matches(msg, keys.Search):
+			m.searching = true
+			m.search.SetValue(m.term)
+			m.search.CursorEnd()
+			return m, m.search.Focus()
+		case key.Matches(msg, keys.NextMatch):
+			return m.jumpToMatch(1), nil
+		case key.Matches(msg, keys.PrevMatch):
+			return m.jumpToMatch(-1), nil
+		case key.Matches(msg, keys.ColumnFilter):
+			if column := m.currentColumn(); column != "" {
+				m.filterColumn = column
+				m.columnFilter.Prompt = column + " "
+				m.columnFilter.SetValue(m.view.Filters[column].String())
+				m.columnFilter.CursorEnd()
+				return m, m.columnFilter.Focus()
+			}
+		case key.Matches(msg, keys.SortRows):
+			if column := m.currentColumn(); column != "" {
+				return m.sortRows(column), nil
+			}
+		default:
+			if m.browse != nil {
+				return m.updateBrowse(msg, conn)
+			}
 		}
 	}
 
@@ -74,16 +374,36 @@ func truncateString(s string, length int) string {
 	return s
 }
 
-func renderColumns(result *db.ExecuteResult, width int) string {
+func renderColumns(result *db.ExecuteResult, browse *Browse, view rowView, width int) string {
 	content := make([]string, 0)
 
 	for _, column := range result.Columns {
+		title := column
+		if browse != nil {
+			if _, ok := browse.ReferenceFrom(column); ok {
+				title += " →"
+			}
+		}
+		if view.SortColumn == column {
+			if view.SortDesc {
+				title += " ▼"
+			} else {
+				title += " ▲"
+			}
+		} else if view.SortColumn == "" && browse != nil && browse.SortColumn == column {
+			if browse.SortDesc {
+				title += " ↓"
+			} else {
+				title += " ↑"
+			}
+		}
+
 		content = append(content,
 			lipgloss.NewStyle().
 				Padding(0, 1).
-				Background(lipgloss.Color("238")).
+				Background(theme.Current.Header).
 				Width(getWidthFromColumn(column)).
-				Render(fmt.Sprintf("%v", column)))
+				Render(title))
 	}
 
 	joined := lipgloss.JoinHorizontal(lipgloss.Left, content...)
@@ -91,7 +411,7 @@ func renderColumns(result *db.ExecuteResult, width int) string {
 	return lipgloss.NewStyle().MaxWidth(width).Render(joined)
 }
 
-func renderRow(columns []string, data map[string]interface{}, cursorColumnIndex int, width int) string {
+func renderRow(columns []string, data map[string]interface{}, cursorColumnIndex int, picked bool, term string, width int) string {
 	var content string
 
 	for idx, column := range columns {
@@ -99,10 +419,27 @@ func renderRow(columns []string, data map[string]interface{}, cursorColumnIndex
 		width := getWidthFromColumn(column)
 		truncated := truncateString(fmt.Sprintf("%v", data[column]), width-2)
 
-		if cursorColumnIndex == idx {
-			content += lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#000000")).
-				Background(lipgloss.Color("#ffffff")).
+		if picked && idx == 0 {
+			// The mark takes the place of the cell's left padding.
+			content += theme.Style(theme.Current.Accent).
+				Bold(true).
+				PaddingRight(1).
+				Width(width).
+				Render("*" + truncated)
+		} else if cursorColumnIndex == idx {
+			content += theme.Selection().
+				Padding(0, 1).
+				Width(width).
+				Render(truncated)
+		} else if matches(data[column], term) {
+			content += theme.Style(theme.Current.SelectionForeground).
+				Background(theme.Current.Warning).
+				Padding(0, 1).
+				Width(width).
+				Render(truncated)
+		} else if picked {
+			content += theme.Style(theme.Current.Accent).
+				Bold(true).
 				Padding(0, 1).
 				Width(width).
 				Render(truncated)
@@ -117,10 +454,15 @@ func renderRow(columns []string, data map[string]interface{}, cursorColumnIndex
 	return lipgloss.NewStyle().MaxWidth(width).Render(content)
 }
 
-func renderRows(result *db.ExecuteResult, cursor Cursor, width int) string {
-	var content string
+// renderRows draws up to height rows, scrolled to keep the cursor in view,
+// highlighting the cells that match term and marking the picked rows.
+func renderRows(columns []string, rows []map[string]interface{}, picked []bool, cursor Cursor, term string, width int, height int) string {
+	lines := make([]string, 0, height)
 
-	for idx, row := range result.Rows {
+	start := design.ScrollStart(cursor.Row, height)
+
+	for idx := start; idx < len(rows) && idx-start < height; idx++ {
+		row := rows[idx]
 
 		isRowSelected := cursor.Row == idx
 
@@ -130,23 +472,120 @@ func renderRows(result *db.ExecuteResult, cursor Cursor, width int) string {
 			columnIndex = cursor.Column
 		}
 
-		content += renderRow(result.Columns, row, columnIndex, width) + "\n"
+		lines = append(lines, renderRow(columns, row, columnIndex, idx < len(picked) && picked[idx], term, width))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (m Model) renderBrowseStatus() string {
+	status := fmt.Sprintf("%s · page %d", m.renderBreadcrumb(), m.browse.Page())
+
+	if m.browse.Where != "" {
+		status += " · WHERE " + m.browse.Where
 	}
 
-	return content
+	return theme.Style(theme.Current.Muted).Render(status)
+}
+
+func (m Model) renderFooter() string {
+	footer := fmt.Sprintf("Executed in %d microseconds", m.result.Microseconds)
+
+	if m.browse != nil {
+		footer = lipgloss.JoinVertical(lipgloss.Left, footer, m.renderBrowseStatus())
+	}
+	if status := m.renderViewStatus(); status != "" {
+		footer = lipgloss.JoinVertical(lipgloss.Left, footer, theme.Style(theme.Current.Muted).Render(status))
+	}
+	if m.notice != "" {
+		footer = lipgloss.JoinVertical(lipgloss.Left, footer, theme.Style(theme.Current.Warning).Render(m.notice))
+	}
+	switch {
+	case m.filtering:
+		footer = lipgloss.JoinVertical(lipgloss.Left, footer, m.filter.View())
+	case m.searching:
+		footer = lipgloss.JoinVertical(lipgloss.Left, footer, m.search.View())
+	case m.filterColumn != "":
+		footer = lipgloss.JoinVertical(lipgloss.Left, footer, m.columnFilter.View())
+	}
+
+	return footer
+}
+
+// visibleRows is how many rows fit between the tab bar and header and
+// the footer.
+func visibleRows(contentHeight int, footer string) int {
+	return contentHeight - 2 - lipgloss.Height(footer)
 }
 
 func (m Model) View(selected bool, width int, height int) string {
+	contentWidth, contentHeight := design.ContentSize(width, height)
 	content := fmt.Sprintf("Execute a query to see the results here...")
 
 	if m.result != nil {
+		footer := m.renderFooter()
+
 		content = lipgloss.JoinVertical(
 			lipgloss.Top,
-			renderColumns(m.result, width-2),
-			renderRows(m.result, m.cursor, width-2),
-			fmt.Sprintf("Executed in %d microseconds", m.result.Microseconds),
+			m.renderTabs(contentWidth),
+			renderColumns(m.result, m.browse, m.view, contentWidth),
+			renderRows(m.result.Columns, m.rows(), m.pickedRows(), m.cursor, m.term, contentWidth, visibleRows(contentHeight, footer)),
+			footer,
 		)
+
+		if m.picker != nil {
+			content = m.renderPicker()
+		}
+		if m.diff != nil {
+			content = m.diff.View(contentWidth, contentHeight)
+		}
+	}
+
+	if m.err != nil {
+		content = lipgloss.JoinVertical(
+			lipgloss.Top,
+			theme.Style(theme.Current.Error).Render(m.err.Error()),
+			theme.Style(theme.Current.Muted).Render(m.err.Query),
+		)
+	}
+
+	return design.Pane{Index: 4, Title: "Results", Selected: selected}.Render(width, height, content)
+}
+
+func (m Model) ShortHelp() []key.Binding {
+	keys := keymap.Keys.Result
+	dialog := keymap.Keys.Dialog
+
+	switch {
+	case m.Filtering():
+		return []key.Binding{dialog.Submit, dialog.Cancel}
+	case m.picker != nil:
+		return []key.Binding{keys.Up, keys.Down, dialog.Submit, dialog.Cancel}
+	case m.diff != nil:
+		return []key.Binding{keys.Up, keys.Down, keys.DiffKey, keys.Diff}
+	case m.browse != nil:
+		bindings := []key.Binding{
+			keys.Sort, keys.Filter, keys.QuickFilter, keys.NextPage, keys.PrevPage,
+			keys.FollowReference, keys.ReferencedBy,
+		}
+		if len(m.history) > 0 {
+			bindings = append(bindings, keys.Back)
+		}
+		return bindings
 	}
 
-	return design.CreatePane(4, "Results", selected, width, height, content)
+	return []key.Binding{keys.Up, keys.Down, keys.Search, keys.ColumnFilter, keys.SortRows, keys.NextTab, keys.CloseTab, keys.Diff}
+}
+
+func (m Model) FullHelp() [][]key.Binding {
+	keys := keymap.Keys.Result
+
+	return [][]key.Binding{
+		{keys.Up, keys.Down, keys.Left, keys.Right},
+		{keys.Search, keys.NextMatch, keys.PrevMatch, keys.ColumnFilter, keys.SortRows},
+		{keys.Sort, keys.Filter, keys.QuickFilter, keys.NextPage, keys.PrevPage},
+		{keys.FollowReference, keys.ReferencedBy, keys.Back},
+		{keys.NextTab, keys.PrevTab, keys.PinTab, keys.CloseTab},
+		{keys.MarkTab, keys.Diff, keys.DiffKey},
+	}
 }
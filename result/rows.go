@@ -0,0 +1,247 @@
+package result
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	db "gosuite/db"
+)
+
+// rowView is the order of the rows on screen, kept apart from the fetched
+// result so sorting and filtering never go back to the database.
+type rowView struct {
+	SortColumn string
+	SortDesc   bool
+
+	// Filters hides rows whose column doesn't match the predicate.
+	Filters map[string]Predicate
+
+	order []int
+}
+
+// apply works out which rows of result to show, and in which order.
+func (v rowView) apply(result *db.ExecuteResult) rowView {
+	v.order = nil
+	if result == nil {
+		return v
+	}
+
+	order := make([]int, 0, len(result.Rows))
+
+rows:
+	for idx, row := range result.Rows {
+		for column, predicate := range v.Filters {
+			if !predicate.Match(row[column]) {
+				continue rows
+			}
+		}
+		order = append(order, idx)
+	}
+
+	if v.SortColumn != "" {
+		sort.SliceStable(order, func(i, j int) bool {
+			a, b := result.Rows[order[i]][v.SortColumn], result.Rows[order[j]][v.SortColumn]
+
+			// NULLs go last whichever way round.
+			if a == nil || b == nil {
+				return a != nil && b == nil
+			}
+			if v.SortDesc {
+				return Compare(b, a) < 0
+			}
+			return Compare(a, b) < 0
+		})
+	}
+
+	v.order = order
+
+	return v
+}
+
+// sortBy cycles column through ascending, descending and unsorted.
+func (v rowView) sortBy(column string) rowView {
+	switch {
+	case v.SortColumn != column:
+		v.SortColumn, v.SortDesc = column, false
+	case !v.SortDesc:
+		v.SortDesc = true
+	default:
+		v.SortColumn, v.SortDesc = "", false
+	}
+	return v
+}
+
+func (v rowView) filter(column string, predicate Predicate) rowView {
+	filters := make(map[string]Predicate, len(v.Filters)+1)
+	for c, p := range v.Filters {
+		filters[c] = p
+	}
+
+	if predicate.Op == "" {
+		delete(filters, column)
+	} else {
+		filters[column] = predicate
+	}
+
+	v.Filters = filters
+	return v
+}
+
+func (v rowView) describeFilters() string {
+	columns := make([]string, 0, len(v.Filters))
+	for column := range v.Filters {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	filters := make([]string, len(columns))
+	for idx, column := range columns {
+		filters[idx] = column + " " + v.Filters[column].String()
+	}
+
+	return strings.Join(filters, " · ")
+}
+
+var dateLayouts = []string{
+	"2006-01-02 15:04:05.999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	time.RFC3339Nano,
+}
+
+func parseNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	}
+
+	f, err := strconv.ParseFloat(strings.TrimSpace(cell(value)), 64)
+	return f, err == nil
+}
+
+func parseDate(value interface{}) (time.Time, bool) {
+	if t, ok := value.(time.Time); ok {
+		return t, true
+	}
+
+	s := strings.TrimSpace(cell(value))
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// Compare orders two non-NULL values as numbers, then as dates, then as
+// text, whichever both of them can be read as.
+func Compare(a interface{}, b interface{}) int {
+	if x, ok := parseNumber(a); ok {
+		if y, ok := parseNumber(b); ok {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+
+	if x, ok := parseDate(a); ok {
+		if y, ok := parseDate(b); ok {
+			return x.Compare(y)
+		}
+	}
+
+	return strings.Compare(cell(a), cell(b))
+}
+
+// Predicate is a column filter such as "> 10", "!= draft", "is null" or
+// plain text, which matches cells containing it.
+type Predicate struct {
+	Op    string
+	Value string
+}
+
+var predicateOps = []string{">=", "<=", "!=", "=", ">", "<", "~"}
+
+func ParsePredicate(input string) (Predicate, error) {
+	input = strings.TrimSpace(input)
+
+	switch strings.ToLower(strings.Join(strings.Fields(input), " ")) {
+	case "":
+		return Predicate{}, nil
+	case "is null":
+		return Predicate{Op: "is null"}, nil
+	case "is not null":
+		return Predicate{Op: "is not null"}, nil
+	}
+
+	for _, op := range predicateOps {
+		if strings.HasPrefix(input, op) {
+			value := strings.TrimSpace(strings.TrimPrefix(input, op))
+			if value == "" {
+				return Predicate{}, fmt.Errorf("%s needs a value", op)
+			}
+			return Predicate{Op: op, Value: value}, nil
+		}
+	}
+
+	return Predicate{Op: "~", Value: input}, nil
+}
+
+func (p Predicate) String() string {
+	if p.Value == "" {
+		return p.Op
+	}
+	return p.Op + " " + p.Value
+}
+
+func (p Predicate) Match(value interface{}) bool {
+	switch p.Op {
+	case "":
+		return true
+	case "is null":
+		return value == nil
+	case "is not null":
+		return value != nil
+	}
+
+	if value == nil {
+		return false
+	}
+
+	if p.Op == "~" {
+		return strings.Contains(strings.ToLower(cell(value)), strings.ToLower(p.Value))
+	}
+
+	comparison := Compare(value, p.Value)
+
+	switch p.Op {
+	case "=":
+		return comparison == 0
+	case "!=":
+		return comparison != 0
+	case ">":
+		return comparison > 0
+	case ">=":
+		return comparison >= 0
+	case "<":
+		return comparison < 0
+	case "<=":
+		return comparison <= 0
+	}
+
+	return false
+}
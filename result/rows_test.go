@@ -0,0 +1,172 @@
+package result
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	db "gosuite/db"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b interface{}
+		want int
+	}{
+		{"9", "10", -1},
+		{int64(10), "9.5", 1},
+		{"2024-01-02", "2023-12-31 23:59:59", 1},
+		{"apple", "banana", -1},
+		{"10", "apple", -1},
+		{"7", "7.0", 0},
+	}
+
+	for _, tt := range tests {
+		if got := Compare(tt.a, tt.b); got != tt.want {
+			t.Errorf("Expected Compare(%v, %v) = %d, got %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
+
+func TestParsePredicate(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Predicate
+	}{
+		{"> 10", Predicate{">", "10"}},
+		{">=3", Predicate{">=", "3"}},
+		{"!= draft", Predicate{"!=", "draft"}},
+		{"IS  NULL", Predicate{Op: "is null"}},
+		{"is not null", Predicate{Op: "is not null"}},
+		{"go", Predicate{"~", "go"}},
+		{"  ", Predicate{}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParsePredicate(tt.input)
+		if err != nil {
+			t.Errorf("Expected no error for %q, got %v", tt.input, err)
+		}
+		if got != tt.want {
+			t.Errorf("Expected %q to parse as %v, got %v", tt.input, tt.want, got)
+		}
+	}
+
+	if _, err := ParsePredicate(">="); err == nil {
+		t.Errorf("Expected an error for an operator without a value")
+	}
+}
+
+func books() *db.ExecuteResult {
+	return &db.ExecuteResult{
+		Query:   "SELECT * FROM books",
+		Columns: []string{"id", "title", "published"},
+		Rows: []map[string]interface{}{
+			{"id": int64(1), "title": "Go", "published": "2015-10-26"},
+			{"id": int64(2), "title": "SQL", "published": nil},
+			{"id": int64(10), "title": "Gophers", "published": "2009-11-10"},
+			{"id": int64(3), "title": "Rust", "published": "2018-05-01"},
+		},
+	}
+}
+
+func ids(result *db.ExecuteResult, view rowView) []interface{} {
+	ids := make([]interface{}, len(view.order))
+	for idx, row := range view.order {
+		ids[idx] = result.Rows[row]["id"]
+	}
+	return ids
+}
+
+func TestRowViewSortsNullsLast(t *testing.T) {
+	result := books()
+
+	view := rowView{}.sortBy("published").apply(result)
+	if got := ids(result, view); got[0] != int64(10) || got[3] != int64(2) {
+		t.Errorf("Expected oldest first and NULL last, got %v", got)
+	}
+
+	view = view.sortBy("published").apply(result)
+	if got := ids(result, view); got[0] != int64(3) || got[3] != int64(2) {
+		t.Errorf("Expected newest first and NULL still last, got %v", got)
+	}
+
+	view = rowView{}.sortBy("id").apply(result)
+	if got := ids(result, view); got[2] != int64(3) || got[3] != int64(10) {
+		t.Errorf("Expected ids sorted as numbers, got %v", got)
+	}
+
+	view = view.sortBy("id").sortBy("id").apply(result)
+	if view.SortColumn != "" || ids(result, view)[2] != int64(10) {
+		t.Errorf("Expected the third sort to restore the fetched order, got %v", ids(result, view))
+	}
+}
+
+func TestRowViewFilters(t *testing.T) {
+	result := books()
+
+	view := rowView{}.filter("title", Predicate{"~", "go"}).apply(result)
+	if got := ids(result, view); len(got) != 2 {
+		t.Errorf("Expected 2 titles containing go, got %v", got)
+	}
+
+	view = view.filter("id", Predicate{">", "5"}).apply(result)
+	if got := ids(result, view); len(got) != 1 || got[0] != int64(10) {
+		t.Errorf("Expected only id 10, got %v", got)
+	}
+
+	view = view.filter("title", Predicate{}).filter("id", Predicate{}).
+		filter("published", Predicate{Op: "is null"}).apply(result)
+	if got := ids(result, view); len(got) != 1 || got[0] != int64(2) {
+		t.Errorf("Expected only the NULL row, got %v", got)
+	}
+}
+
+func TestSearchAndSortInModel(t *testing.T) {
+	m, _ := InitModel().Update(*books(), true, nil)
+
+	m = press(m, "/")
+	if !m.Filtering() {
+		t.Fatalf("Expected the search bar to take the keys")
+	}
+	m = press(m, "go")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter}, true, nil)
+
+	if m.cursor != (Cursor{Row: 0, Column: 1}) {
+		t.Errorf("Expected the first match at 0,1, got %v", m.cursor)
+	}
+
+	m = press(m, "n")
+	if m.cursor != (Cursor{Row: 2, Column: 1}) {
+		t.Errorf("Expected the next match at 2,1, got %v", m.cursor)
+	}
+
+	m = press(m, "n")
+	if m.cursor != (Cursor{Row: 0, Column: 1}) {
+		t.Errorf("Expected the search to wrap to 0,1, got %v", m.cursor)
+	}
+
+	m = press(m, "N")
+	if m.cursor != (Cursor{Row: 2, Column: 1}) {
+		t.Errorf("Expected the previous match to wrap to 2,1, got %v", m.cursor)
+	}
+
+	// Sorting keeps the cursor on the same row.
+	m.cursor.Column = 0
+	m = press(m, "o")
+	m = press(m, "o")
+	if row := m.currentRow(); row["id"] != int64(10) || m.cursor.Row != 0 {
+		t.Errorf("Expected id 10 at the top after sorting descending, got %v at %d", row["id"], m.cursor.Row)
+	}
+
+	m = press(m, "F")
+	m = press(m, "< 3")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter}, true, nil)
+
+	if len(m.rows()) != 2 || len(m.result.Rows) != 4 {
+		t.Errorf("Expected 2 of 4 rows left, got %d of %d", len(m.rows()), len(m.result.Rows))
+	}
+	if m.Filtering() {
+		t.Errorf("Expected the filter bar to close")
+	}
+}
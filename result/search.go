@@ -0,0 +1,182 @@
+package result
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	keymap "gosuite/keymap"
+)
+
+// matches reports whether a cell contains term, ignoring case.
+func matches(value interface{}, term string) bool {
+	if term == "" || value == nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(cell(value)), strings.ToLower(term))
+}
+
+// matchCursors lists every cell on screen matching the search term, row by
+// row.
+func (m Model) matchCursors() []Cursor {
+	cursors := make([]Cursor, 0)
+	if m.result == nil || m.term == "" {
+		return cursors
+	}
+
+	for row, data := range m.rows() {
+		for column, name := range m.result.Columns {
+			if matches(data[name], m.term) {
+				cursors = append(cursors, Cursor{Row: row, Column: column})
+			}
+		}
+	}
+
+	return cursors
+}
+
+func before(a Cursor, b Cursor) bool {
+	return a.Row < b.Row || (a.Row == b.Row && a.Column < b.Column)
+}
+
+// jumpToMatch moves the cursor to the next match after it, or the previous
+// one when direction is negative, wrapping around the ends.
+func (m Model) jumpToMatch(direction int) Model {
+	cursors := m.matchCursors()
+	if len(cursors) == 0 {
+		if m.term != "" {
+			m.notice = fmt.Sprintf("No cells match %q", m.term)
+		}
+		return m
+	}
+
+	start := m.cursor
+
+	if direction > 0 {
+		m.cursor = cursors[0]
+		for _, cursor := range cursors {
+			if before(start, cursor) {
+				m.cursor = cursor
+				break
+			}
+		}
+		return m
+	}
+
+	m.cursor = cursors[len(cursors)-1]
+	for idx := len(cursors) - 1; idx >= 0; idx-- {
+		if before(cursors[idx], start) {
+			m.cursor = cursors[idx]
+			break
+		}
+	}
+	return m
+}
+
+func (m Model) updateSearch(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keymap.Keys.Dialog.Cancel):
+		m.searching = false
+		m.search.Blur()
+		return m, nil
+	case key.Matches(msg, keymap.Keys.Dialog.Submit):
+		m.searching = false
+		m.search.Blur()
+		m.term = strings.TrimSpace(m.search.Value())
+
+		// Start from just before the cursor so a match under it counts.
+		cursor := m.cursor
+		m.cursor.Column--
+		m = m.jumpToMatch(1)
+		if len(m.matchCursors()) == 0 {
+			m.cursor = cursor
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.search, cmd = m.search.Update(msg)
+
+	return m, cmd
+}
+
+func (m Model) updateColumnFilter(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keymap.Keys.Dialog.Cancel):
+		m.filterColumn = ""
+		m.columnFilter.Blur()
+		return m, nil
+	case key.Matches(msg, keymap.Keys.Dialog.Submit):
+		predicate, err := ParsePredicate(m.columnFilter.Value())
+		if err != nil {
+			m.notice = err.Error()
+			return m, nil
+		}
+
+		column := m.filterColumn
+		m.filterColumn = ""
+		m.columnFilter.Blur()
+		m.notice = ""
+
+		return m.reorder(m.view.filter(column, predicate)), nil
+	}
+
+	var cmd tea.Cmd
+	m.notice = ""
+	m.columnFilter, cmd = m.columnFilter.Update(msg)
+
+	return m, cmd
+}
+
+func (m Model) sortRows(column string) Model {
+	return m.reorder(m.view.sortBy(column))
+}
+
+// reorder shows the rows in view's order, keeping the cursor on the same
+// row if it's still there.
+func (m Model) reorder(view rowView) Model {
+	selected := -1
+	if m.cursor.Row < len(m.view.order) {
+		selected = m.view.order[m.cursor.Row]
+	}
+
+	m.view = view.apply(m.result)
+	m.cursor.Row = 0
+
+	for idx, row := range m.view.order {
+		if row == selected {
+			m.cursor.Row = idx
+			break
+		}
+	}
+
+	return m
+}
+
+// renderViewStatus describes the search, filters and sort applied on top
+// of the fetched rows.
+func (m Model) renderViewStatus() string {
+	parts := make([]string, 0)
+
+	if len(m.view.Filters) > 0 {
+		parts = append(parts,
+			fmt.Sprintf("%d of %d rows", len(m.view.order), len(m.result.Rows)),
+			m.view.describeFilters(),
+		)
+	}
+
+	if m.term != "" {
+		cursors := m.matchCursors()
+		current := 0
+		for idx, cursor := range cursors {
+			if cursor == m.cursor {
+				current = idx + 1
+			}
+		}
+		parts = append(parts, fmt.Sprintf("match %d/%d for %q", current, len(cursors), m.term))
+	}
+
+	return strings.Join(parts, " · ")
+}
@@ -0,0 +1,202 @@
+package result
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	db "gosuite/db"
+	theme "gosuite/theme"
+)
+
+// MaxTabs is how many result sets are kept before the oldest unpinned one
+// is closed.
+const MaxTabs = 10
+
+// Tab is a result set kept around to switch back to or compare against.
+type Tab struct {
+	Query  string
+	At     time.Time
+	Pinned bool
+
+	result  *db.ExecuteResult
+	cursor  Cursor
+	browse  *Browse
+	history []crumb
+	view    rowView
+}
+
+func (t Tab) Result() *db.ExecuteResult {
+	return t.result
+}
+
+func (t Tab) Label() string {
+	query := strings.Join(strings.Fields(t.Query), " ")
+	if query == "" {
+		query = "…"
+	}
+
+	return t.At.Format("15:04:05") + " " + truncateString(query, 24)
+}
+
+func (m Model) Tabs() []Tab {
+	return m.stash().tabs
+}
+
+func (m Model) CurrentTab() int {
+	return m.current
+}
+
+// stash saves the result being shown back into its tab.
+func (m Model) stash() Model {
+	if m.current >= len(m.tabs) {
+		return m
+	}
+
+	m.tabs = append([]Tab{}, m.tabs...)
+
+	tab := &m.tabs[m.current]
+	tab.result = m.result
+	tab.cursor = m.cursor
+	tab.browse = m.browse
+	tab.history = m.history
+	tab.view = m.view
+
+	return m
+}
+
+func (m Model) load(idx int) Model {
+	m.current = idx
+	m.diff = nil
+	m.err = nil
+	m.notice = ""
+	m.picker = nil
+	m.restore = nil
+	m.pending = nil
+	m.result = nil
+	m.cursor = Cursor{}
+	m.browse = nil
+	m.history = nil
+	m.view = rowView{}
+	m.term = ""
+
+	if idx < len(m.tabs) {
+		tab := m.tabs[idx]
+		m.result = tab.result
+		m.cursor = tab.cursor
+		m.browse = tab.browse
+		m.history = tab.history
+		m.view = tab.view
+	}
+
+	return m
+}
+
+func (m Model) switchTab(idx int) Model {
+	if idx < 0 || idx >= len(m.tabs) || idx == m.current {
+		return m
+	}
+	return m.stash().load(idx)
+}
+
+// openTab shows a new, empty tab for query, reusing the current tab if
+// nothing has arrived in it yet.
+func (m Model) openTab(query string) Model {
+	if m.current < len(m.tabs) && m.result == nil {
+		m.tabs = append([]Tab{}, m.tabs...)
+		m.tabs[m.current].Query = query
+		m.tabs[m.current].At = time.Now()
+		return m.load(m.current)
+	}
+
+	m = m.stash()
+	m.tabs = append(m.tabs, Tab{Query: query, At: time.Now()})
+	m.current = len(m.tabs) - 1
+	m = m.evict()
+
+	return m.load(m.current)
+}
+
+// evict closes the oldest unpinned tabs until there are at most MaxTabs.
+func (m Model) evict() Model {
+	for len(m.tabs) > MaxTabs {
+		oldest := -1
+		for idx, tab := range m.tabs {
+			if !tab.Pinned && idx != m.current {
+				oldest = idx
+				break
+			}
+		}
+		if oldest < 0 {
+			break
+		}
+
+		m.tabs = append(m.tabs[:oldest:oldest], m.tabs[oldest+1:]...)
+		if m.current > oldest {
+			m.current--
+		}
+	}
+
+	return m
+}
+
+func (m Model) closeTab() Model {
+	if m.current >= len(m.tabs) {
+		return m
+	}
+
+	m.tabs = append(m.tabs[:m.current:m.current], m.tabs[m.current+1:]...)
+
+	return m.load(min(m.current, max(len(m.tabs)-1, 0)))
+}
+
+func (m Model) togglePin() Model {
+	if m.current >= len(m.tabs) {
+		return m
+	}
+
+	m.tabs = append([]Tab{}, m.tabs...)
+	m.tabs[m.current].Pinned = !m.tabs[m.current].Pinned
+
+	return m
+}
+
+func (m Model) tabLabel(idx int) string {
+	label := " " + m.tabs[idx].Label() + " "
+	if m.tabs[idx].Pinned {
+		label = " •" + label
+	}
+	if m.marked != nil && m.tabs[idx].result == m.marked {
+		label = " *" + label
+	}
+	return label
+}
+
+// tabAt returns the tab drawn at x in the tab bar.
+func (m Model) tabAt(x int) (int, bool) {
+	left := 0
+
+	for idx := range m.tabs {
+		left += lipgloss.Width(m.tabLabel(idx)) + 1
+		if x < left {
+			return idx, true
+		}
+	}
+
+	return 0, false
+}
+
+func (m Model) renderTabs(width int) string {
+	labels := make([]string, len(m.tabs))
+
+	for idx := range m.tabs {
+		style := lipgloss.NewStyle().Background(theme.Current.Header)
+		if idx == m.current {
+			style = theme.Selection()
+		}
+		labels[idx] = style.Render(m.tabLabel(idx))
+	}
+
+	return lipgloss.NewStyle().MaxWidth(width).Render(strings.Join(labels, " "))
+}
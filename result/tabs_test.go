@@ -0,0 +1,87 @@
+package result
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	db "gosuite/db"
+)
+
+func run(m Model, query string) Model {
+	m, _ = m.Update(db.ExecuteResult{
+		Query:   query,
+		Columns: []string{"id"},
+		Rows:    []map[string]interface{}{{"id": 1}, {"id": 2}},
+	}, true, nil)
+	return m
+}
+
+func press(m Model, key string) Model {
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}, true, nil)
+	return m
+}
+
+func TestTabs(t *testing.T) {
+	m := run(run(InitModel(), "SELECT 1"), "SELECT 2")
+
+	if len(m.Tabs()) != 2 || m.CurrentTab() != 1 {
+		t.Fatalf("Expected 2 tabs on the second, got %d on %d", len(m.Tabs()), m.CurrentTab())
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown}, true, nil)
+	m = press(m, "[")
+
+	if m.result.Query != "SELECT 1" || m.cursor.Row != 0 {
+		t.Errorf("Expected SELECT 1 at row 0, got %s at %d", m.result.Query, m.cursor.Row)
+	}
+
+	m = press(m, "]")
+
+	if m.result.Query != "SELECT 2" || m.cursor.Row != 1 {
+		t.Errorf("Expected SELECT 2 back at row 1, got %s at %d", m.result.Query, m.cursor.Row)
+	}
+
+	m = press(m, "x")
+
+	if len(m.Tabs()) != 1 || m.result.Query != "SELECT 1" {
+		t.Errorf("Expected only SELECT 1 left, got %d tabs showing %s", len(m.Tabs()), m.result.Query)
+	}
+}
+
+func TestBrowsePagesStayInTab(t *testing.T) {
+	m := InitModel().openTab("SELECT * FROM `authors`")
+	browse := NewBrowse("authors", []string{"id"})
+	m.browse = &browse
+
+	m = run(m, browse.SQL())
+	m = run(m, browse.SQL())
+
+	if len(m.Tabs()) != 1 || m.browse == nil {
+		t.Errorf("Expected the browse to stay in one tab, got %d tabs", len(m.Tabs()))
+	}
+}
+
+func TestEvictionKeepsPinnedTabs(t *testing.T) {
+	m := press(run(InitModel(), "SELECT 0"), "p")
+
+	for idx := 1; idx <= MaxTabs+2; idx++ {
+		m = run(m, fmt.Sprintf("SELECT %d", idx))
+	}
+
+	tabs := m.Tabs()
+
+	if len(tabs) != MaxTabs {
+		t.Fatalf("Expected %d tabs, got %d", MaxTabs, len(tabs))
+	}
+	if tabs[0].Query != "SELECT 0" || !tabs[0].Pinned {
+		t.Errorf("Expected the pinned tab to be kept, got %s", tabs[0].Query)
+	}
+	if tabs[1].Query != "SELECT 4" {
+		t.Errorf("Expected SELECT 1 to 3 to be evicted, got %s", tabs[1].Query)
+	}
+	if m.CurrentTab() != MaxTabs-1 || m.result.Query != fmt.Sprintf("SELECT %d", MaxTabs+2) {
+		t.Errorf("Expected the newest tab to be shown, got %d", m.CurrentTab())
+	}
+}
@@ -0,0 +1,316 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectConfigName is the file a repository commits to add or override
+// connections for everyone working in it.
+const ProjectConfigName = ".gosuite.yml"
+
+// FindProjectConfig walks up from dir to the first directory holding a
+// project config.
+func FindProjectConfig(dir string) (string, bool) {
+	for {
+		path := filepath.Join(dir, ProjectConfigName)
+		if fileExists(path) {
+			return path, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// GlobalConfigPath is the --config flag when given, then GOSUITE_CONFIG,
+// then config.pkl or config.yml in the XDG config directory.
+func GlobalConfigPath(override string) string {
+	if override != "" {
+		return override
+	}
+	if env := os.Getenv("GOSUITE_CONFIG"); env != "" {
+		return env
+	}
+	if path := getPklConfigPath(); fileExists(path) {
+		return path
+	}
+	return getConfigPath()
+}
+
+// Layers is the effective config document, merged from several files,
+// remembering which file every node came from.
+type Layers struct {
+	Paths []string
+
+	doc     *yaml.Node
+	sources map[*yaml.Node]string
+}
+
+func (l *Layers) add(path string, doc *yaml.Node) {
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		l.sources[node] = path
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(doc)
+
+	l.Paths = append(l.Paths, path)
+
+	if l.doc == nil {
+		l.doc = doc
+		return
+	}
+	l.doc.Content[0] = merge(l.doc.Content[0], doc.Content[0], "")
+}
+
+func lookup(mapping *yaml.Node, key string) (*yaml.Node, int) {
+	if mapping.Kind != yaml.MappingNode {
+		return nil, -1
+	}
+	for idx := 0; idx+1 < len(mapping.Content); idx += 2 {
+		if mapping.Content[idx].Value == key {
+			return mapping.Content[idx+1], idx + 1
+		}
+	}
+	return nil, -1
+}
+
+// merge lays src over dst: mappings key by key, the databases list by
+// connection name, and anything else replaced whole.
+func merge(dst *yaml.Node, src *yaml.Node, key string) *yaml.Node {
+	switch {
+	case dst.Kind == yaml.MappingNode && src.Kind == yaml.MappingNode:
+		for idx := 0; idx+1 < len(src.Content); idx += 2 {
+			name, value := src.Content[idx], src.Content[idx+1]
+
+			if existing, at := lookup(dst, name.Value); existing != nil {
+				dst.Content[at] = merge(existing, value, name.Value)
+			} else {
+				dst.Content = append(dst.Content, name, value)
+			}
+		}
+		return dst
+
+	case key == "databases" && dst.Kind == yaml.SequenceNode && src.Kind == yaml.SequenceNode:
+	entries:
+		for _, entry := range src.Content {
+			if name, _ := lookup(entry, "name"); name != nil {
+				for idx, existing := range dst.Content {
+					if other, _ := lookup(existing, "name"); other != nil && other.Value == name.Value {
+						dst.Content[idx] = merge(existing, entry, "")
+						continue entries
+					}
+				}
+			}
+			dst.Content = append(dst.Content, entry)
+		}
+		return dst
+	}
+
+	return src
+}
+
+// pklLayer encodes a config evaluated from Pkl as a layer. Host and port
+// left unset are left out, as they would be in a YAML file, rather than
+// set to "" and 0.
+func pklLayer(config *AppConfig) (*yaml.Node, error) {
+	var root yaml.Node
+	if err := root.Encode(config); err != nil {
+		return nil, err
+	}
+
+	if databases, _ := lookup(&root, "databases"); databases != nil {
+		for _, entry := range databases.Content {
+			kept := entry.Content[:0]
+			for idx := 0; idx+1 < len(entry.Content); idx += 2 {
+				key, value := entry.Content[idx], entry.Content[idx+1]
+				if (key.Value == "host" && value.Value == "") || (key.Value == "port" && value.Value == "0") {
+					continue
+				}
+				kept = append(kept, key, value)
+			}
+			entry.Content = kept
+		}
+	}
+
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{&root}}, nil
+}
+
+func readLayer(path string) (*yaml.Node, error) {
+	if filepath.Ext(path) == ".pkl" {
+		config := &AppConfig{}
+		if err := LoadPklConfig(context.Background(), path, config); err != nil {
+			return nil, err
+		}
+
+		return pklLayer(config)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s: expected a mapping at the top level", path)
+	}
+
+	return &doc, nil
+}
+
+// LoadLayers merges the files in order, later ones winning, and decodes
+// the result. Only unreadable files are errors, anything else wrong is
+// collected in the config's Problems.
+func LoadLayers(paths ...string) (*AppConfig, *Layers, error) {
+	layers := &Layers{sources: make(map[*yaml.Node]string)}
+
+	for _, path := range paths {
+		doc, err := readLayer(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		layers.add(path, doc)
+	}
+
+	config := &AppConfig{Files: paths}
+	if len(paths) > 0 {
+		config.Path = paths[0]
+	}
+
+	layers.decode(config)
+
+	return config, layers, nil
+}
+
+// Source is where the value of node was set, as file:line.
+func (l *Layers) Source(node *yaml.Node) string {
+	path := l.sources[node]
+	if node.Line == 0 {
+		return path
+	}
+	return fmt.Sprintf("%s:%d", path, node.Line)
+}
+
+func isScalarList(node *yaml.Node) bool {
+	if node.Kind != yaml.SequenceNode {
+		return false
+	}
+	for _, child := range node.Content {
+		if child.Kind != yaml.ScalarNode {
+			return false
+		}
+	}
+	return true
+}
+
+// annotated copies node with every value commented with its source and
+// every password redacted.
+func (l *Layers) annotated(node *yaml.Node) *yaml.Node {
+	copied := *node
+	copied.HeadComment, copied.LineComment, copied.FootComment = "", "", ""
+	copied.Content = make([]*yaml.Node, len(node.Content))
+
+	for idx, child := range node.Content {
+		copied.Content[idx] = l.annotated(child)
+	}
+
+	if copied.Kind != yaml.MappingNode {
+		return &copied
+	}
+
+	for idx := 0; idx+1 < len(copied.Content); idx += 2 {
+		key, value := copied.Content[idx], copied.Content[idx+1]
+
+		if value.Kind == yaml.ScalarNode || isScalarList(value) {
+			value.LineComment = l.Source(node.Content[idx+1])
+		}
+		if key.Value == "password" && value.Kind == yaml.ScalarNode && value.Value != "" {
+			value.Value, value.Tag, value.Style = "********", "!!str", 0
+		}
+		if key.Value == "url" && value.Kind == yaml.ScalarNode {
+			value.Value = redactURL(value.Value)
+		}
+	}
+
+	return &copied
+}
+
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return raw
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), "********")
+	}
+	return u.String()
+}
+
+// Show writes the effective config as YAML, with passwords redacted and
+// where each value came from.
+func (l *Layers) Show(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "# Merged from: %v\n", l.Paths); err != nil {
+		return err
+	}
+	if l.doc == nil {
+		return nil
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(l.annotated(l.doc)); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// GetConfigLayers finds the global and project files and merges them.
+func GetConfigLayers(override string) (*AppConfig, *Layers, error) {
+	global := GlobalConfigPath(override)
+
+	if global == getConfigPath() {
+		if err := CreateConfigIfMissing(global); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	paths := []string{global}
+
+	if cwd, err := os.Getwd(); err == nil {
+		if project, ok := FindProjectConfig(cwd); ok && project != global {
+			paths = append(paths, project)
+		}
+	}
+
+	config, layers, err := LoadLayers(paths...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := addDatabaseURL(config, layers, os.LookupEnv); err != nil {
+		return nil, nil, err
+	}
+
+	return config, layers, nil
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path string, content string) string {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const globalConfig = `databases:
+  - name: local
+    user: root
+    password: global-secret
+    host: localhost
+    port: 3306
+    database: blog
+  - name: production
+    user: app
+    password: prod-secret
+    host: db.example.com
+    port: 3306
+    database: blog
+    environment: production
+theme:
+  name: dark
+`
+
+const projectConfig = `databases:
+  - name: local
+    port: 3307
+    database: orders
+  - name: docker
+    user: root
+    host: 127.0.0.1
+    port: 3308
+    database: orders
+`
+
+func TestFindProjectConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, filepath.Join(dir, ProjectConfigName), projectConfig)
+
+	nested := filepath.Join(dir, "cmd", "server")
+	os.MkdirAll(nested, 0700)
+
+	found, ok := FindProjectConfig(nested)
+	if !ok || found != path {
+		t.Errorf("Expected %s, got %s", path, found)
+	}
+}
+
+func TestLoadLayersMergesByName(t *testing.T) {
+	dir := t.TempDir()
+	global := writeFile(t, filepath.Join(dir, "config.yml"), globalConfig)
+	project := writeFile(t, filepath.Join(dir, "repo", ProjectConfigName), projectConfig)
+
+	config, _, err := LoadLayers(global, project)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(config.Databases) != 3 {
+		t.Fatalf("Expected 3 databases, got %d", len(config.Databases))
+	}
+
+	local := config.Databases[0]
+	if local.Port != 3307 || local.Database != "orders" || local.User != "root" || local.Password != "global-secret" {
+		t.Errorf("Expected the project to override only port and database, got %+v", local)
+	}
+	if config.Databases[1].Name != "production" || config.Databases[2].Name != "docker" {
+		t.Errorf("Expected production kept and docker added, got %+v", config.Databases)
+	}
+	if config.Theme.Name != "dark" {
+		t.Errorf("Expected the global theme to be kept, got %q", config.Theme.Name)
+	}
+	if config.Path != global {
+		t.Errorf("Expected the global path, got %s", config.Path)
+	}
+}
+
+func TestShowRedactsAndAnnotates(t *testing.T) {
+	dir := t.TempDir()
+	global := writeFile(t, filepath.Join(dir, "config.yml"), globalConfig)
+	project := writeFile(t, filepath.Join(dir, "repo", ProjectConfigName), projectConfig)
+
+	_, layers, err := LoadLayers(global, project)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := layers.Show(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	if strings.Contains(out, "secret") {
+		t.Errorf("Expected passwords to be redacted, got\n%s", out)
+	}
+	if !strings.Contains(out, "port: 3307 # "+project+":3") {
+		t.Errorf("Expected the port to come from the project file, got\n%s", out)
+	}
+	if !strings.Contains(out, "user: root # "+global+":3") {
+		t.Errorf("Expected the user to come from the global file, got\n%s", out)
+	}
+}
+
+func TestGlobalConfigPath(t *testing.T) {
+	t.Setenv("GOSUITE_CONFIG", "/etc/gosuite.yml")
+
+	if path := GlobalConfigPath("ci.yml"); path != "ci.yml" {
+		t.Errorf("Expected the flag to win, got %s", path)
+	}
+	if path := GlobalConfigPath(""); path != "/etc/gosuite.yml" {
+		t.Errorf("Expected GOSUITE_CONFIG, got %s", path)
+	}
+}
+
+func TestShowDatabaseURL(t *testing.T) {
+	global := writeFile(t, filepath.Join(t.TempDir(), "config.yml"), globalConfig)
+
+	config, layers, err := LoadLayers(global)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lookup := env(map[string]string{"DATABASE_URL": "mysql://app:secret@db:3306/orders"})
+	if err := addDatabaseURL(config, layers, lookup); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := layers.Show(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	if !strings.Contains(out, "name: DATABASE_URL # $DATABASE_URL") {
+		t.Errorf("Expected the DATABASE_URL connection with its source, got\n%s", out)
+	}
+	if !strings.Contains(out, "url: mysql://app:%2A%2A%2A%2A%2A%2A%2A%2A@db:3306/orders # $DATABASE_URL") {
+		t.Errorf("Expected the redacted URL, got\n%s", out)
+	}
+	if strings.Contains(out, "secret") {
+		t.Errorf("Expected the password to be redacted, got\n%s", out)
+	}
+}
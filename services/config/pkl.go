@@ -0,0 +1,107 @@
+package config
+
+//go:generate pkl-gen-go pkl/Config.pkl --generator-settings pkl/generator-settings.pkl
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/apple/pkl-go/pkl"
+
+	"gosuite/services/config/pklconfig"
+)
+
+func getPklConfigPath() string {
+	return filepath.Join(filepath.Dir(getConfigPath()), "config.pkl")
+}
+
+func duration(d *pkl.Duration) time.Duration {
+	if d == nil {
+		return 0
+	}
+	return d.GoDuration()
+}
+
+func fromPkl(cfg *pklconfig.Config) AppConfig {
+	config := AppConfig{}
+
+	for _, db := range cfg.Databases {
+		database := DatabaseConfig{
+			Name:            db.Name,
+			User:            db.User,
+			Password:        db.Password,
+			Host:            db.Host,
+			Database:        db.Database,
+			ReadOnly:        db.ReadOnly,
+			Environment:     db.Environment,
+			ConnMaxLifetime: duration(db.ConnMaxLifetime),
+			ConnectTimeout:  duration(db.ConnectTimeout),
+		}
+		if db.Url != nil {
+			database.URL = *db.Url
+		}
+		if db.Port != nil {
+			database.Port = int(*db.Port)
+		}
+		if db.Tls != nil {
+			database.TLS = *db.Tls
+		}
+		if db.MaxOpen != nil {
+			database.MaxOpen = int(*db.MaxOpen)
+		}
+		if db.MaxIdle != nil {
+			database.MaxIdle = int(*db.MaxIdle)
+		}
+		config.Databases = append(config.Databases, database)
+	}
+
+	if cfg.ProcessList != nil {
+		config.ProcessList.Interval = duration(cfg.ProcessList.Interval)
+	}
+
+	if cfg.Dashboard != nil {
+		config.Dashboard.Interval = duration(cfg.Dashboard.Interval)
+	}
+
+	if cfg.Keybindings != nil {
+		if cfg.Keybindings.Preset != nil {
+			config.Keybindings.Preset = *cfg.Keybindings.Preset
+		}
+		config.Keybindings.Bindings = cfg.Keybindings.Bindings
+	}
+
+	if cfg.Theme != nil {
+		if cfg.Theme.Name != nil {
+			config.Theme.Name = *cfg.Theme.Name
+		}
+		config.Theme.Colors = cfg.Theme.Colors
+	}
+
+	if cfg.Layout != nil {
+		config.Layout = LayoutConfig{
+			SidebarWidth:     int(cfg.Layout.SidebarWidth),
+			DatabaseHeight:   int(cfg.Layout.DatabaseHeight),
+			QueryHeight:      int(cfg.Layout.QueryHeight),
+			SidebarCollapsed: cfg.Layout.SidebarCollapsed,
+		}
+	}
+
+	return config
+}
+
+// LoadPklConfig evaluates a config.pkl into config, which needs the pkl
+// command on the PATH.
+func LoadPklConfig(ctx context.Context, path string, config *AppConfig) error {
+	cfg, err := pklconfig.LoadFromPath(ctx, path)
+
+	if err != nil {
+		return err
+	}
+
+	loaded := fromPkl(cfg)
+	loaded.Path = config.Path
+	*config = loaded
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apple/pkl-go/pkl"
+	"github.com/vmihailenco/msgpack/v5"
+	"gopkg.in/yaml.v3"
+
+	"gosuite/services/config/pklconfig"
+)
+
+// object encodes a Pkl object the way the evaluator sends it, so decoding
+// can be tested without the pkl command.
+func object(class string, properties ...interface{}) []interface{} {
+	members := make([]interface{}, 0, len(properties)/2)
+	for idx := 0; idx < len(properties); idx += 2 {
+		members = append(members, []interface{}{0x10, properties[idx], properties[idx+1]})
+	}
+	return []interface{}{0x1, class, "file:///config.pkl", members}
+}
+
+func TestDecodePklConfig(t *testing.T) {
+	data, err := msgpack.Marshal(object("gosuite.Config",
+		"databases", []interface{}{0x5, []interface{}{
+			object("gosuite.Config#DatabaseConfig",
+				"name", "production",
+				"user", "app",
+				"password", "secret",
+				"host", "db.example.com",
+				"port", 3307,
+				"database", "blog",
+				"readOnly", true,
+				"environment", "production",
+			),
+		}},
+		"processList", object("gosuite.Config#ProcessListConfig",
+			"interval", []interface{}{0x7, 1.5, "s"},
+		),
+		"dashboard", nil,
+		"keybindings", object("gosuite.Config#KeybindingsConfig",
+			"preset", "vim",
+			"bindings", []interface{}{0x3, map[string]interface{}{
+				"result.sort": []interface{}{0x5, []interface{}{"o"}},
+			}},
+		),
+		"theme", nil,
+		"layout", object("gosuite.Config#LayoutConfig",
+			"sidebarWidth", 30,
+			"databaseHeight", 0,
+			"queryHeight", 10,
+			"sidebarCollapsed", true,
+		),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cfg pklconfig.Config
+	if err := pkl.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	config := fromPkl(&cfg)
+
+	want := DatabaseConfig{
+		Name:        "production",
+		User:        "app",
+		Password:    "secret",
+		Host:        "db.example.com",
+		Port:        3307,
+		Database:    "blog",
+		ReadOnly:    true,
+		Environment: "production",
+	}
+	if len(config.Databases) != 1 || config.Databases[0] != want {
+		t.Errorf("Expected %+v, got %+v", want, config.Databases)
+	}
+
+	if config.ProcessList.Interval != 1500*time.Millisecond {
+		t.Errorf("Expected 1.5s, got %v", config.ProcessList.Interval)
+	}
+	if config.Dashboard.Interval != 0 {
+		t.Errorf("Expected no dashboard interval, got %v", config.Dashboard.Interval)
+	}
+	if config.Keybindings.Preset != "vim" || config.Keybindings.Bindings["result.sort"][0] != "o" {
+		t.Errorf("Expected the vim preset with result.sort on o, got %+v", config.Keybindings)
+	}
+	if config.Theme.Name != "" {
+		t.Errorf("Expected no theme, got %q", config.Theme.Name)
+	}
+	if config.Layout != (LayoutConfig{SidebarWidth: 30, QueryHeight: 10, SidebarCollapsed: true}) {
+		t.Errorf("Expected the layout to carry over, got %+v", config.Layout)
+	}
+}
+
+// TestLoadPklConfig runs the real evaluator, so it only runs where the pkl
+// command is installed.
+func TestLoadPklConfig(t *testing.T) {
+	if _, err := exec.LookPath("pkl"); err != nil {
+		t.Skip("pkl is not installed")
+	}
+
+	path := filepath.Join("testdata", "config.pkl")
+
+	config, _, err := LoadLayers(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(config.Databases) != 2 {
+		t.Fatalf("Expected 2 databases, got %d", len(config.Databases))
+	}
+
+	staging, production := config.Databases[0], config.Databases[1]
+	if staging.User != "app" || production.User != "app" || production.Host != "db.example.com" {
+		t.Errorf("Expected both to amend the base connection, got %+v and %+v", staging, production)
+	}
+	if !production.IsProduction() || staging.Host != "localhost" || staging.Port != 3306 {
+		t.Errorf("Expected the schema defaults and overrides, got %+v and %+v", staging, production)
+	}
+	if config.Path != path {
+		t.Errorf("Expected the path to be kept, got %s", config.Path)
+	}
+	if len(config.Problems) != 0 || len(config.Invalid) != 0 {
+		t.Errorf("Expected both connections to be valid, got %v", config.Problems)
+	}
+}
+
+func TestPklLayerLeavesOutUnsetHost(t *testing.T) {
+	doc, err := pklLayer(&AppConfig{Databases: []DatabaseConfig{{Name: "staging", User: "app"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	layers := &Layers{sources: make(map[*yaml.Node]string)}
+	layers.add("config.pkl", doc)
+
+	config := &AppConfig{}
+	layers.decode(config)
+
+	if len(config.Problems) != 0 || len(config.Databases) != 1 {
+		t.Fatalf("Expected staging to be valid, got %v", config.Problems)
+	}
+	if db, err := config.Databases[0].Resolve(); err != nil || db.Host != "localhost" || db.Port != 3306 {
+		t.Errorf("Expected localhost:3306, got %s:%d (%v)", db.Host, db.Port, err)
+	}
+}
+
+func TestSetSectionRejectsPkl(t *testing.T) {
+	if err := SaveLayout(filepath.Join(t.TempDir(), "config.pkl"), LayoutConfig{}); err == nil {
+		t.Errorf("Expected an error saving into a Pkl config")
+	}
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Reload loads the files of cfg again.
+func Reload(cfg *AppConfig) (*AppConfig, error) {
+	config, _, err := LoadLayers(cfg.Files...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := addDatabaseURL(config, nil, os.LookupEnv); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// Changes are the connections added, removed or with different settings
+// after a reload, by name.
+type Changes struct {
+	Added   []string
+	Removed []string
+	Changed []string
+}
+
+func DiffDatabases(before []DatabaseConfig, after []DatabaseConfig) Changes {
+	changes := Changes{}
+	previous := make(map[string]DatabaseConfig, len(before))
+
+	for _, db := range before {
+		previous[db.Name] = db
+	}
+
+	for _, db := range after {
+		old, ok := previous[db.Name]
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, db.Name)
+		case old != db:
+			changes.Changed = append(changes.Changed, db.Name)
+		}
+		delete(previous, db.Name)
+	}
+
+	for _, db := range before {
+		if _, ok := previous[db.Name]; ok {
+			changes.Removed = append(changes.Removed, db.Name)
+		}
+	}
+
+	return changes
+}
+
+func (c Changes) String() string {
+	parts := []string{}
+
+	for _, change := range []struct {
+		verb  string
+		names []string
+	}{{"added", c.Added}, {"changed", c.Changed}, {"removed", c.Removed}} {
+		if len(change.names) > 0 {
+			parts = append(parts, fmt.Sprintf("%s %s", change.verb, strings.Join(change.names, ", ")))
+		}
+	}
+
+	if len(parts) == 0 {
+		return "no connection changes"
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDiffDatabases(t *testing.T) {
+	before := []DatabaseConfig{{Name: "local", Port: 3306}, {Name: "staging"}, {Name: "old"}}
+	after := []DatabaseConfig{{Name: "local", Port: 3307}, {Name: "staging"}, {Name: "new"}}
+
+	changes := DiffDatabases(before, after)
+
+	if want := "added new; changed local; removed old"; changes.String() != want {
+		t.Errorf("Expected %q, got %q", want, changes.String())
+	}
+
+	if got := DiffDatabases(before, before).String(); got != "no connection changes" {
+		t.Errorf("Expected no changes, got %q", got)
+	}
+}
+
+func TestReload(t *testing.T) {
+	dir := t.TempDir()
+	global := writeFile(t, filepath.Join(dir, "config.yml"), "databases:\n  - name: local\n    port: 3306\n")
+	project := writeFile(t, filepath.Join(dir, ProjectConfigName), "databases:\n  - name: project\n")
+
+	cfg, _, err := LoadLayers(global, project)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	writeFile(t, project, "databases:\n  - name: local\n    port: 3307\n")
+
+	reloaded, err := Reload(cfg)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(reloaded.Databases) != 1 || reloaded.Databases[0].Port != 3307 {
+		t.Errorf("Expected the project file to win, got %+v", reloaded.Databases)
+	}
+}
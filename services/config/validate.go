@@ -0,0 +1,306 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is something wrong with the config, at the place it was set.
+type Problem struct {
+	Path    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (p Problem) Error() string {
+	if p.Line == 0 {
+		return fmt.Sprintf("%s: %s", p.Path, p.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", p.Path, p.Line, p.Column, p.Message)
+}
+
+type Problems []Problem
+
+func (p Problems) Error() string {
+	lines := make([]string, len(p))
+	for idx, problem := range p {
+		lines[idx] = problem.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// InvalidDatabase is a connection left out of Databases because of its
+// problems.
+type InvalidDatabase struct {
+	Name     string
+	Problems Problems
+}
+
+func (l *Layers) problem(node *yaml.Node, format string, args ...interface{}) Problem {
+	return Problem{
+		Path:    l.sources[node],
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func (l *Layers) position(node *yaml.Node) string {
+	if node.Line == 0 {
+		return l.sources[node]
+	}
+	return fmt.Sprintf("%s:%d:%d", l.sources[node], node.Line, node.Column)
+}
+
+// yamlKeys lists the keys a struct decodes from.
+func yamlKeys(t reflect.Type) []string {
+	keys := make([]string, 0, t.NumField())
+
+	for idx := 0; idx < t.NumField(); idx++ {
+		name, _, _ := strings.Cut(t.Field(idx).Tag.Get("yaml"), ",")
+		if name != "" && name != "-" {
+			keys = append(keys, name)
+		}
+	}
+
+	return keys
+}
+
+func distance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+
+	return previous[len(b)]
+}
+
+// suggest returns the known key closest to a typo'd one.
+func suggest(key string, known []string) (string, bool) {
+	best, bestDistance := "", 3
+
+	for _, candidate := range known {
+		if d := distance(strings.ToLower(key), candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+
+	return best, best != ""
+}
+
+func (l *Layers) checkKeys(mapping *yaml.Node, t reflect.Type) Problems {
+	problems := Problems{}
+	known := yamlKeys(t)
+
+keys:
+	for idx := 0; idx+1 < len(mapping.Content); idx += 2 {
+		key := mapping.Content[idx]
+
+		for _, k := range known {
+			if k == key.Value {
+				continue keys
+			}
+		}
+
+		if suggestion, ok := suggest(key.Value, known); ok {
+			problems = append(problems, l.problem(key, "unknown key %q, did you mean %q?", key.Value, suggestion))
+		} else {
+			problems = append(problems, l.problem(key, "unknown key %q", key.Value))
+		}
+	}
+
+	return problems
+}
+
+var typeErrorLine = regexp.MustCompile(`^line (\d+): (.*)$`)
+
+// find returns the last scalar on a line, which is the value when the line
+// holds a key and its value.
+func find(node *yaml.Node, line int) *yaml.Node {
+	var found *yaml.Node
+	if node.Line == line && node.Kind == yaml.ScalarNode {
+		found = node
+	}
+	for _, child := range node.Content {
+		if f := find(child, line); f != nil {
+			found = f
+		}
+	}
+	return found
+}
+
+// decodeProblems turns the error of decoding node into problems placed at
+// the nodes that failed.
+func (l *Layers) decodeProblems(node *yaml.Node, err error) Problems {
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		return Problems{l.problem(node, "%v", err)}
+	}
+
+	problems := Problems{}
+	for _, message := range typeErr.Errors {
+		at := node
+		if match := typeErrorLine.FindStringSubmatch(message); match != nil {
+			line, _ := strconv.Atoi(match[1])
+			if found := find(node, line); found != nil {
+				at = found
+			}
+			message = match[2]
+		}
+		problems = append(problems, l.problem(at, "%s", message))
+	}
+	return problems
+}
+
+// decodeDatabase decodes and checks one connection entry.
+func (l *Layers) decodeDatabase(entry *yaml.Node, env func(string) (string, bool)) (DatabaseConfig, Problems) {
+	db := DatabaseConfig{}
+
+	if entry.Kind != yaml.MappingNode {
+		return db, Problems{l.problem(entry, "expected the settings of a connection")}
+	}
+
+	problems := l.checkKeys(entry, reflect.TypeOf(db))
+
+	// A type error still decodes the other fields, so they're checked too.
+	err := entry.Decode(&db)
+	if err != nil {
+		problems = append(problems, l.decodeProblems(entry, err)...)
+	}
+
+	if db.Name == "" {
+		problems = append(problems, l.problem(entry, "connection has no name"))
+	}
+
+	if port, _ := lookup(entry, "port"); port != nil && err == nil && (db.Port < 1 || db.Port > 65535) {
+		problems = append(problems, l.problem(port, "port must be between 1 and 65535, got %d", db.Port))
+	}
+
+	for _, setting := range []struct {
+		key      string
+		negative bool
+	}{
+		{"max_open", db.MaxOpen < 0},
+		{"max_idle", db.MaxIdle < 0},
+		{"conn_max_lifetime", db.ConnMaxLifetime < 0},
+		{"connect_timeout", db.ConnectTimeout < 0},
+	} {
+		if value, _ := lookup(entry, setting.key); value != nil && setting.negative {
+			problems = append(problems, l.problem(value, "%s must not be negative, got %s", setting.key, value.Value))
+		}
+	}
+
+	if host, _ := lookup(entry, "host"); host != nil && strings.TrimSpace(db.Host) == "" {
+		problems = append(problems, l.problem(host, "host is empty, leave it out for localhost"))
+	}
+
+	if len(problems) > 0 {
+		return db, problems
+	}
+
+	if err := db.resolve(env); err != nil {
+		at := entry
+		if name, _ := lookup(entry, "name"); name != nil {
+			at = name
+		}
+		problems = append(problems, l.problem(at, "%v", err))
+	}
+
+	return db, problems
+}
+
+// decode builds the config from the merged document, leaving out the
+// parts with problems instead of failing on them.
+func (l *Layers) decode(config *AppConfig) {
+	if l.doc == nil {
+		return
+	}
+
+	root := l.doc.Content[0]
+	config.Problems = append(config.Problems, l.checkKeys(root, reflect.TypeOf(*config))...)
+
+	sections := map[string]interface{}{
+		"process_list": &config.ProcessList,
+		"dashboard":    &config.Dashboard,
+		"keybindings":  &config.Keybindings,
+		"theme":        &config.Theme,
+		"layout":       &config.Layout,
+	}
+
+	for idx := 0; idx+1 < len(root.Content); idx += 2 {
+		key, value := root.Content[idx], root.Content[idx+1]
+
+		if key.Value == "databases" {
+			l.decodeDatabases(config, value)
+			continue
+		}
+
+		target, ok := sections[key.Value]
+		if !ok {
+			continue
+		}
+
+		if value.Kind == yaml.MappingNode {
+			config.Problems = append(config.Problems, l.checkKeys(value, reflect.TypeOf(target).Elem())...)
+		}
+		if err := value.Decode(target); err != nil {
+			config.Problems = append(config.Problems, l.decodeProblems(value, err)...)
+		}
+	}
+}
+
+func (l *Layers) decodeDatabases(config *AppConfig, list *yaml.Node) {
+	if list.Kind != yaml.SequenceNode {
+		config.Problems = append(config.Problems, l.problem(list, "databases should be a list of connections"))
+		return
+	}
+
+	seen := make(map[string]*yaml.Node)
+
+	for idx, entry := range list.Content {
+		db, problems := l.decodeDatabase(entry, os.LookupEnv)
+
+		if name, _ := lookup(entry, "name"); name != nil && db.Name != "" {
+			if first, ok := seen[db.Name]; ok {
+				problems = append(problems, l.problem(name, "duplicate connection name %q, first defined at %s", db.Name, l.position(first)))
+			} else {
+				seen[db.Name] = name
+			}
+		}
+
+		if len(problems) == 0 {
+			config.Databases = append(config.Databases, db)
+			continue
+		}
+
+		name := db.Name
+		if name == "" {
+			name = fmt.Sprintf("databases[%d]", idx)
+		}
+
+		config.Invalid = append(config.Invalid, InvalidDatabase{Name: name, Problems: problems})
+		config.Problems = append(config.Problems, problems...)
+	}
+}
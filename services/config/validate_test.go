@@ -0,0 +1,116 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const brokenConfig = `databases:
+  - name: local
+    user: root
+    pasword: secret
+  - name: staging
+    host: ""
+    port: 0
+  - name: local
+    port: abc
+  - user: nameless
+  - name: fine
+    database: blog
+themes:
+  name: dark
+layout:
+  sidebar_width: wide
+`
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	path := writeFile(t, filepath.Join(t.TempDir(), "config.yml"), brokenConfig)
+
+	config, _, err := LoadLayers(path)
+	if err != nil {
+		t.Fatalf("Expected problems rather than an error, got %v", err)
+	}
+
+	want := []string{
+		path + `:4:5: unknown key "pasword", did you mean "password"?`,
+		path + `:6:11: host is empty, leave it out for localhost`,
+		path + `:7:11: port must be between 1 and 65535, got 0`,
+		path + ":9:11: cannot unmarshal !!str `abc` into int",
+		path + `:10:5: connection has no name`,
+		path + `:13:1: unknown key "themes", did you mean "theme"?`,
+		path + ":16:18: cannot unmarshal !!str `wide` into int",
+	}
+
+	got := config.Problems.Error()
+	for _, line := range want {
+		if !strings.Contains(got, line) {
+			t.Errorf("Expected %q in\n%s", line, got)
+		}
+	}
+
+	if len(config.Databases) != 1 || config.Databases[0].Name != "fine" {
+		t.Errorf("Expected only the valid connection, got %+v", config.Databases)
+	}
+	if len(config.Invalid) != 4 || config.Invalid[3].Name != "databases[3]" {
+		t.Errorf("Expected 4 invalid connections, got %+v", config.Invalid)
+	}
+}
+
+func TestValidateDuplicateNames(t *testing.T) {
+	path := writeFile(t, filepath.Join(t.TempDir(), "config.yml"), `databases:
+  - name: local
+  - name: local
+`)
+
+	config, _, _ := LoadLayers(path)
+
+	want := path + `:3:11: duplicate connection name "local", first defined at ` + path + ":2:11"
+	if len(config.Problems) != 1 || config.Problems[0].Error() != want {
+		t.Errorf("Expected %q, got %v", want, config.Problems)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	known := []string{"name", "host", "port", "password", "read_only"}
+
+	for typo, want := range map[string]string{"prot": "port", "readonly": "read_only", "Host": "host"} {
+		if got, _ := suggest(typo, known); got != want {
+			t.Errorf("Expected %s for %s, got %s", want, typo, got)
+		}
+	}
+
+	if got, ok := suggest("environment", known); ok {
+		t.Errorf("Expected no suggestion, got %s", got)
+	}
+}
+
+func TestValidatePoolSettings(t *testing.T) {
+	path := writeFile(t, filepath.Join(t.TempDir(), "config.yml"), `databases:
+  - name: local
+    max_open: 10
+    conn_max_lifetime: 30m
+    connect_timeout: 5s
+  - name: broken
+    max_idle: -1
+    connect_timeout: soon
+`)
+
+	config, _, _ := LoadLayers(path)
+
+	want := DatabaseConfig{Name: "local", Host: "localhost", Port: 3306, MaxOpen: 10, ConnMaxLifetime: 30 * time.Minute, ConnectTimeout: 5 * time.Second}
+	if len(config.Databases) != 1 || config.Databases[0] != want {
+		t.Errorf("Expected %+v, got %+v", want, config.Databases)
+	}
+
+	got := config.Problems.Error()
+	for _, line := range []string{
+		path + ":7:15: max_idle must not be negative, got -1",
+		path + ":8:22: cannot unmarshal !!str `soon` into time.Duration",
+	} {
+		if !strings.Contains(got, line) {
+			t.Errorf("Expected %q in\n%s", line, got)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// editDocument parses the YAML file at path, lets edit change its top-level
+// mapping and writes it back, keeping the comments and order of everything
+// edit didn't touch.
+func editDocument(path string, edit func(root *yaml.Node) error) error {
+	if filepath.Ext(path) == ".pkl" {
+		return fmt.Errorf("%s is a Pkl config, edit it by hand", path)
+	}
+
+	info, err := os.Stat(path)
+
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return err
+	}
+
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s: expected a mapping at the top level", path)
+	}
+
+	if err := edit(root); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(&doc); err != nil {
+		return err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, buf.Bytes(), info.Mode().Perm())
+}
+
+// SetSection replaces the top-level key of the YAML file at path with
+// value, or adds it at the end, leaving the rest of the file and its
+// comments as they were.
+func SetSection(path string, key string, value interface{}) error {
+	if filepath.Ext(path) == ".pkl" {
+		return fmt.Errorf("%s is a Pkl config, set %s in it by hand", path, key)
+	}
+
+	return editDocument(path, func(root *yaml.Node) error {
+		var node yaml.Node
+		if err := node.Encode(value); err != nil {
+			return err
+		}
+
+		if existing, idx := lookup(root, key); existing != nil {
+			node.HeadComment = existing.HeadComment
+			node.LineComment = existing.LineComment
+			root.Content[idx] = &node
+			return nil
+		}
+
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, &node)
+		return nil
+	})
+}
+
+func SaveLayout(path string, layout LayoutConfig) error {
+	return SetSection(path, "layout", layout)
+}
+
+// findEntry returns the index of the named connection in the databases
+// list.
+func findEntry(list *yaml.Node, name string) int {
+	for idx, entry := range list.Content {
+		if value, _ := lookup(entry, "name"); value != nil && value.Value == name {
+			return idx
+		}
+	}
+	return -1
+}
+
+func databaseList(root *yaml.Node, path string) (*yaml.Node, error) {
+	list, _ := lookup(root, "databases")
+
+	if list == nil {
+		list = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "databases"}, list)
+	}
+
+	if list.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("%s: databases should be a list of connections", path)
+	}
+
+	return list, nil
+}
+
+func isZero(value *yaml.Node) bool {
+	switch value.Tag {
+	case "!!str":
+		return value.Value == ""
+	case "!!int":
+		return value.Value == "0"
+	case "!!bool":
+		return value.Value == "false"
+	}
+	return false
+}
+
+// setFields writes db into entry, updating the keys already there in place
+// and leaving out the ones that are unset.
+func setFields(entry *yaml.Node, db DatabaseConfig) error {
+	var encoded yaml.Node
+	if err := encoded.Encode(db); err != nil {
+		return err
+	}
+
+	// Encoding drops the omitempty keys that are unset, so they're removed
+	// here.
+	for _, key := range yamlKeys(reflect.TypeOf(db)) {
+		if value, _ := lookup(&encoded, key); value != nil {
+			continue
+		}
+		if _, at := lookup(entry, key); at >= 0 {
+			entry.Content = append(entry.Content[:at-1], entry.Content[at+1:]...)
+		}
+	}
+
+	for idx := 0; idx+1 < len(encoded.Content); idx += 2 {
+		key, value := encoded.Content[idx], encoded.Content[idx+1]
+		existing, at := lookup(entry, key.Value)
+
+		switch {
+		case isZero(value) && key.Value != "name":
+			if existing != nil {
+				entry.Content = append(entry.Content[:at-1], entry.Content[at+1:]...)
+			}
+
+		case existing != nil && existing.Kind == yaml.ScalarNode:
+			// A quoted string stays quoted.
+			if value.Tag != "!!str" || existing.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) == 0 {
+				existing.Style = value.Style
+			}
+			existing.Tag, existing.Value = value.Tag, value.Value
+
+		case existing != nil:
+			entry.Content[at] = value
+
+		default:
+			entry.Content = append(entry.Content, key, value)
+		}
+	}
+
+	return nil
+}
+
+// SaveDatabase writes db to the YAML file at path, replacing the connection
+// named original or adding a new one when original is empty.
+func SaveDatabase(path string, original string, db DatabaseConfig) error {
+	return editDocument(path, func(root *yaml.Node) error {
+		list, err := databaseList(root, path)
+		if err != nil {
+			return err
+		}
+
+		if db.Name != original && findEntry(list, db.Name) >= 0 {
+			return fmt.Errorf("a connection named %q already exists in %s", db.Name, path)
+		}
+
+		if original == "" {
+			entry := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			list.Content = append(list.Content, entry)
+			return setFields(entry, db)
+		}
+
+		idx := findEntry(list, original)
+		if idx < 0 {
+			return fmt.Errorf("no connection named %q in %s", original, path)
+		}
+
+		return setFields(list.Content[idx], db)
+	})
+}
+
+// DeleteDatabase removes the named connection from the YAML file at path.
+func DeleteDatabase(path string, name string) error {
+	return editDocument(path, func(root *yaml.Node) error {
+		list, err := databaseList(root, path)
+		if err != nil {
+			return err
+		}
+
+		idx := findEntry(list, name)
+		if idx < 0 {
+			return fmt.Errorf("no connection named %q in %s", name, path)
+		}
+
+		list.Content = append(list.Content[:idx], list.Content[idx+1:]...)
+		return nil
+	})
+}
+
+// FindDatabase returns the named connection as written in the last of files
+// that has it, before any variables or URL are expanded, and that file.
+func FindDatabase(files []string, name string) (string, DatabaseConfig, error) {
+	for idx := len(files) - 1; idx >= 0; idx-- {
+		doc, err := readLayer(files[idx])
+		if err != nil {
+			return "", DatabaseConfig{}, err
+		}
+
+		list, _ := lookup(doc.Content[0], "databases")
+		if list == nil || list.Kind != yaml.SequenceNode {
+			continue
+		}
+
+		if at := findEntry(list, name); at >= 0 {
+			db := DatabaseConfig{}
+			err := list.Content[at].Decode(&db)
+			return files[idx], db, err
+		}
+	}
+
+	return "", DatabaseConfig{}, fmt.Errorf("%q is not defined in a config file", name)
+}
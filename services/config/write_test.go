@@ -0,0 +1,101 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveLayout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+
+	if err := CreateConfigIfMissing(path); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, height := range []int{20, 25} {
+		if err := SaveLayout(path, LayoutConfig{SidebarWidth: 30, QueryHeight: height}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(data), "# password_cmd:") {
+		t.Errorf("Expected comments to be kept, got:\n%s", data)
+	}
+	if strings.Count(string(data), "\nlayout:") != 1 {
+		t.Errorf("Expected a single layout section, got:\n%s", data)
+	}
+
+	config := &AppConfig{}
+	if err := LoadConfig(path, config); err != nil {
+		t.Fatal(err)
+	}
+
+	if config.Layout.QueryHeight != 25 || config.Layout.SidebarWidth != 30 {
+		t.Errorf("Expected the saved layout, got %v", config.Layout)
+	}
+	if len(config.Databases) != 1 || config.Databases[0].Name != "default" {
+		t.Errorf("Expected the databases to be kept, got %v", config.Databases)
+	}
+}
+
+func TestSaveDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+
+	if err := CreateConfigIfMissing(path); err != nil {
+		t.Fatal(err)
+	}
+
+	edited := DatabaseConfig{Name: "local", User: "app", Password: "${DB_PASSWORD}", Host: "db.internal", Port: 3307, Database: "blog"}
+	if err := SaveDatabase(path, "default", edited); err != nil {
+		t.Fatal(err)
+	}
+
+	added := DatabaseConfig{Name: "staging", URL: "mysql://app@staging/blog", ReadOnly: true}
+	if err := SaveDatabase(path, "", added); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SaveDatabase(path, "", added); err == nil {
+		t.Errorf("Expected an error for a duplicate name")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(data), `  - name: "local"
+    user: "app"
+    password: "${DB_PASSWORD}"
+    # password_cmd:`) {
+		t.Errorf("Expected the entry to be edited in place, got:\n%s", data)
+	}
+	if !strings.Contains(string(data), "  - name: staging\n    url: mysql://app@staging/blog\n    read_only: true\n") {
+		t.Errorf("Expected only the set fields of the new entry, got:\n%s", data)
+	}
+
+	file, db, err := FindDatabase([]string{path}, "local")
+	if err != nil || file != path || db != edited {
+		t.Errorf("Expected %+v from %s, got %+v from %s, %v", edited, path, db, file, err)
+	}
+
+	if err := DeleteDatabase(path, "local"); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &AppConfig{}
+	if err := LoadConfig(path, config); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(config.Databases) != 1 || config.Databases[0].Name != "staging" {
+		t.Errorf("Expected only staging to be left, got %+v", config.Databases)
+	}
+}
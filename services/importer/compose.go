@@ -0,0 +1,168 @@
+package importer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	config "gosuite/services/config"
+)
+
+type composeFile struct {
+	Services map[string]composeService `yaml:"services"`
+}
+
+type composeService struct {
+	Image       string      `yaml:"image"`
+	Ports       []yaml.Node `yaml:"ports"`
+	Environment yaml.Node   `yaml:"environment"`
+}
+
+// engine is the database an image runs, or "" for anything else.
+func engine(image string) string {
+	name := image
+	if idx := strings.LastIndex(name, "/"); idx >= 0 {
+		name = name[idx+1:]
+	}
+	name, _, _ = strings.Cut(name, ":")
+
+	switch name {
+	case "mysql", "mariadb", "percona", "percona-server", "mysql-server":
+		return "mysql"
+	case "postgres", "postgis", "timescaledb":
+		return "postgres"
+	}
+	return ""
+}
+
+// environment reads the map and the KEY=value list forms.
+func environment(node yaml.Node) map[string]string {
+	env := map[string]string{}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		node.Decode(&env)
+	case yaml.SequenceNode:
+		var list []string
+		node.Decode(&list)
+		for _, item := range list {
+			if key, value, ok := strings.Cut(item, "="); ok {
+				env[key] = value
+			}
+		}
+	}
+
+	return env
+}
+
+// published returns the host address and port that target is published
+// on, from the short "[ip:]host:container" or the long syntax.
+func published(ports []yaml.Node, target int) (string, int, bool) {
+	for _, port := range ports {
+		switch port.Kind {
+		case yaml.ScalarNode:
+			spec, _, _ := strings.Cut(port.Value, "/")
+			parts := strings.Split(spec, ":")
+			if len(parts) < 2 || parts[len(parts)-1] != strconv.Itoa(target) {
+				continue
+			}
+
+			hostPort, err := strconv.Atoi(parts[len(parts)-2])
+			if err != nil {
+				continue
+			}
+			host := ""
+			if len(parts) == 3 {
+				host = parts[0]
+			}
+			return host, hostPort, true
+
+		case yaml.MappingNode:
+			var long struct {
+				Target    int    `yaml:"target"`
+				Published string `yaml:"published"`
+				HostIP    string `yaml:"host_ip"`
+			}
+			if port.Decode(&long) != nil || long.Target != target {
+				continue
+			}
+			if hostPort, err := strconv.Atoi(long.Published); err == nil {
+				return long.HostIP, hostPort, true
+			}
+		}
+	}
+
+	return "", 0, false
+}
+
+func first(env map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if value := env[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// FromCompose finds the MySQL services of a compose file, using their
+// published port and MYSQL_* or MARIADB_* environment.
+func FromCompose(path string) ([]Candidate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file composeFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	candidates := []Candidate{}
+
+	for name, service := range file.Services {
+		kind := engine(service.Image)
+		if kind == "" {
+			continue
+		}
+
+		candidate := Candidate{
+			Database: config.DatabaseConfig{Name: slug(name), Host: "localhost"},
+			Source:   fmt.Sprintf("%s: %s", filepath.Base(path), name),
+		}
+
+		target := 3306
+		if kind == "postgres" {
+			target = 5432
+			candidate.Unsupported = "Postgres is not supported yet"
+		}
+
+		host, port, ok := published(service.Ports, target)
+		if !ok && candidate.Supported() {
+			candidate.Unsupported = fmt.Sprintf("port %d is not published", target)
+		}
+		if host != "" && host != "0.0.0.0" {
+			candidate.Database.Host = host
+		}
+		candidate.Database.Port = port
+
+		env := environment(service.Environment)
+		candidate.Database.Database = first(env, "MYSQL_DATABASE", "MARIADB_DATABASE", "POSTGRES_DB")
+		candidate.Database.User = first(env, "MYSQL_USER", "MARIADB_USER", "POSTGRES_USER")
+		candidate.Database.Password = first(env, "MYSQL_PASSWORD", "MARIADB_PASSWORD", "POSTGRES_PASSWORD")
+
+		if candidate.Database.User == "" && kind == "mysql" {
+			candidate.Database.User = "root"
+			candidate.Database.Password = first(env, "MYSQL_ROOT_PASSWORD", "MARIADB_ROOT_PASSWORD")
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	sortCandidates(candidates)
+
+	return candidates, nil
+}
@@ -0,0 +1,95 @@
+package importer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	config "gosuite/services/config"
+)
+
+type dataSources struct {
+	Sources []struct {
+		Name     string `xml:"name,attr"`
+		UUID     string `xml:"uuid,attr"`
+		JDBCURL  string `xml:"jdbc-url"`
+		UserName string `xml:"user-name"`
+	} `xml:"component>data-source"`
+}
+
+func readDataSources(path string) (dataSources, error) {
+	var sources dataSources
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return sources, err
+	}
+
+	if err := xml.Unmarshal(data, &sources); err != nil {
+		return sources, fmt.Errorf("%s: %w", path, err)
+	}
+	return sources, nil
+}
+
+// parseJDBC reads a jdbc:mysql:// or jdbc:mariadb:// URL into db, and
+// returns the scheme.
+func parseJDBC(raw string, db *config.DatabaseConfig) (string, error) {
+	u, err := url.Parse(strings.TrimPrefix(raw, "jdbc:"))
+	if err != nil {
+		return "", err
+	}
+
+	db.Host = u.Hostname()
+	db.Port, _ = strconv.Atoi(u.Port())
+	db.Database = strings.TrimPrefix(u.Path, "/")
+
+	if user := u.Query().Get("user"); user != "" {
+		db.User = user
+	}
+
+	return u.Scheme, nil
+}
+
+// FromDataSources reads the data sources of a JetBrains project. Users are
+// kept in dataSources.local.xml next to it, and passwords in the IDE's
+// keychain, so the password is left to fill in.
+func FromDataSources(path string) ([]Candidate, error) {
+	sources, err := readDataSources(path)
+	if err != nil {
+		return nil, err
+	}
+
+	users := map[string]string{}
+	if local, err := readDataSources(filepath.Join(filepath.Dir(path), "dataSources.local.xml")); err == nil {
+		for _, source := range local.Sources {
+			users[source.UUID] = source.UserName
+		}
+	}
+
+	candidates := []Candidate{}
+
+	for _, source := range sources.Sources {
+		candidate := Candidate{
+			Database: config.DatabaseConfig{Name: slug(source.Name), User: users[source.UUID]},
+			Source:   fmt.Sprintf("%s: %s", filepath.Base(path), source.Name),
+		}
+
+		scheme, err := parseJDBC(source.JDBCURL, &candidate.Database)
+		switch {
+		case err != nil:
+			candidate.Unsupported = fmt.Sprintf("cannot read %s", source.JDBCURL)
+		case scheme == "postgresql":
+			candidate.Unsupported = "Postgres is not supported yet"
+		case scheme != "mysql" && scheme != "mariadb":
+			candidate.Unsupported = fmt.Sprintf("%s is not supported", scheme)
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, nil
+}
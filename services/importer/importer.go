@@ -0,0 +1,87 @@
+// Package importer finds connections set up for other tools: compose
+// files, MySQL option files, .pgpass and JetBrains data sources.
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	config "gosuite/services/config"
+)
+
+// Candidate is a connection found outside the config.
+type Candidate struct {
+	Database config.DatabaseConfig
+	// Source is where it was found, as file and section.
+	Source string
+	// Unsupported says why it can't be added, such as it being Postgres.
+	Unsupported string
+}
+
+func (c Candidate) Supported() bool {
+	return c.Unsupported == ""
+}
+
+// Same is true when the candidate points at the same database as db.
+func (c Candidate) Same(db config.DatabaseConfig) bool {
+	found := c.Database
+	return found.Host == db.Host && found.Port == db.Port && found.User == db.User && found.Database == db.Database
+}
+
+var composeFiles = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+// Discover looks for candidates in the project directory dir and the home
+// directory home. Files that are missing or can't be read are skipped.
+func Discover(dir string, home string) []Candidate {
+	candidates := []Candidate{}
+
+	for _, name := range composeFiles {
+		found, _ := FromCompose(filepath.Join(dir, name))
+		candidates = append(candidates, found...)
+	}
+
+	found, _ := FromDataSources(filepath.Join(dir, ".idea", "dataSources.xml"))
+	candidates = append(candidates, found...)
+
+	if home != "" {
+		found, _ = FromMyCnf(filepath.Join(home, ".my.cnf"))
+		candidates = append(candidates, found...)
+
+		found, _ = FromPgpass(filepath.Join(home, ".pgpass"))
+		candidates = append(candidates, found...)
+	}
+
+	return candidates
+}
+
+// slug turns a label into a connection name.
+func slug(label string) string {
+	var out strings.Builder
+	dash := false
+
+	for _, r := range strings.ToLower(label) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_':
+			out.WriteRune(r)
+			dash = false
+		case !dash && out.Len() > 0:
+			out.WriteRune('-')
+			dash = true
+		}
+	}
+
+	return strings.TrimSuffix(out.String(), "-")
+}
+
+func readFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	return string(data), err
+}
+
+func sortCandidates(candidates []Candidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Source < candidates[j].Source
+	})
+}
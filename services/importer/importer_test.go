@@ -0,0 +1,182 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	config "gosuite/services/config"
+)
+
+func writeFile(t *testing.T, path string, content string) string {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const compose = `services:
+  db:
+    image: mysql:8
+    environment:
+      MYSQL_DATABASE: 'test'
+      MYSQL_USER: 'user'
+      MYSQL_PASSWORD: 'password'
+    ports:
+      - '3306:3306'
+  maria:
+    image: docker.io/library/mariadb:11
+    environment:
+      - MARIADB_ROOT_PASSWORD=${ROOT_PASSWORD}
+    ports:
+      - target: 3306
+        published: "3307"
+        host_ip: 127.0.0.1
+  internal:
+    image: mysql:8
+  pg:
+    image: postgres:16
+    ports: ["5432:5432"]
+  web:
+    image: nginx
+`
+
+func TestFromCompose(t *testing.T) {
+	path := writeFile(t, filepath.Join(t.TempDir(), "docker-compose.yml"), compose)
+
+	candidates, err := FromCompose(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Candidate{
+		{Database: config.DatabaseConfig{Name: "db", Host: "localhost", Port: 3306, User: "user", Password: "password", Database: "test"}, Source: "docker-compose.yml: db"},
+		{Database: config.DatabaseConfig{Name: "internal", Host: "localhost", User: "root"}, Source: "docker-compose.yml: internal", Unsupported: "port 3306 is not published"},
+		{Database: config.DatabaseConfig{Name: "maria", Host: "127.0.0.1", Port: 3307, User: "root", Password: "${ROOT_PASSWORD}"}, Source: "docker-compose.yml: maria"},
+		{Database: config.DatabaseConfig{Name: "pg", Host: "localhost", Port: 5432}, Source: "docker-compose.yml: pg", Unsupported: "Postgres is not supported yet"},
+	}
+
+	if len(candidates) != len(want) {
+		t.Fatalf("Expected %d candidates, got %+v", len(want), candidates)
+	}
+	for idx := range want {
+		if candidates[idx] != want[idx] {
+			t.Errorf("Expected %+v, got %+v", want[idx], candidates[idx])
+		}
+	}
+}
+
+func TestFromMyCnf(t *testing.T) {
+	path := writeFile(t, filepath.Join(t.TempDir(), ".my.cnf"), `[mysqld]
+port = 3310
+
+[client]
+user = app
+password = "p#ss word"
+host = db.internal
+
+[client_staging]
+# staging only
+user=reader
+port=3307
+ssl-mode=REQUIRED
+`)
+
+	candidates, err := FromMyCnf(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []config.DatabaseConfig{
+		{Name: "my-cnf", User: "app", Password: "p#ss word", Host: "db.internal"},
+		{Name: "my-cnf-staging", User: "reader", Port: 3307, TLS: "true"},
+	}
+
+	if len(candidates) != len(want) {
+		t.Fatalf("Expected %d candidates, got %+v", len(want), candidates)
+	}
+	for idx := range want {
+		if candidates[idx].Database != want[idx] {
+			t.Errorf("Expected %+v, got %+v", want[idx], candidates[idx].Database)
+		}
+	}
+	if candidates[1].Source != ".my.cnf: [client_staging]" {
+		t.Errorf("Expected the section as the source, got %s", candidates[1].Source)
+	}
+}
+
+func TestFromPgpass(t *testing.T) {
+	path := writeFile(t, filepath.Join(t.TempDir(), ".pgpass"), "# comment\nlocalhost:5432:blog:app:se\\:cret\n*:*:*:admin:pw\n")
+
+	candidates, err := FromPgpass(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(candidates) != 2 {
+		t.Fatalf("Expected 2 candidates, got %+v", candidates)
+	}
+
+	want := config.DatabaseConfig{Name: "pg-localhost-blog", Host: "localhost", Port: 5432, Database: "blog", User: "app", Password: "se:cret"}
+	if candidates[0].Database != want || candidates[0].Source != ".pgpass:2" || candidates[0].Supported() {
+		t.Errorf("Expected %+v from .pgpass:2, unsupported, got %+v", want, candidates[0])
+	}
+}
+
+func TestFromDataSources(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), ".idea")
+
+	path := writeFile(t, filepath.Join(dir, "dataSources.xml"), `<?xml version="1.0" encoding="UTF-8"?>
+<project version="4">
+  <component name="DataSourceManagerImpl" format="xml" multifile-model="true">
+    <data-source source="LOCAL" name="test@localhost" uuid="a1">
+      <driver-ref>mysql.8</driver-ref>
+      <jdbc-url>jdbc:mysql://localhost:3306/test</jdbc-url>
+    </data-source>
+    <data-source source="LOCAL" name="analytics" uuid="b2">
+      <jdbc-url>jdbc:postgresql://localhost:5432/analytics</jdbc-url>
+    </data-source>
+  </component>
+</project>
+`)
+	writeFile(t, filepath.Join(dir, "dataSources.local.xml"), `<?xml version="1.0" encoding="UTF-8"?>
+<project version="4">
+  <component name="dataSourceStorageLocal">
+    <data-source name="test@localhost" uuid="a1">
+      <user-name>user</user-name>
+    </data-source>
+  </component>
+</project>
+`)
+
+	candidates, err := FromDataSources(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := config.DatabaseConfig{Name: "test-localhost", Host: "localhost", Port: 3306, User: "user", Database: "test"}
+	if len(candidates) != 2 || candidates[0].Database != want || !candidates[0].Supported() {
+		t.Fatalf("Expected %+v first, got %+v", want, candidates)
+	}
+	if candidates[1].Supported() {
+		t.Errorf("Expected Postgres to be unsupported, got %+v", candidates[1])
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	dir, home := t.TempDir(), t.TempDir()
+	writeFile(t, filepath.Join(dir, "compose.yaml"), compose)
+	writeFile(t, filepath.Join(home, ".my.cnf"), "[client]\nuser=app\n")
+
+	if got := len(Discover(dir, home)); got != 5 {
+		t.Errorf("Expected 5 candidates, got %d", got)
+	}
+	if got := len(Discover(t.TempDir(), "")); got != 0 {
+		t.Errorf("Expected no candidates, got %d", got)
+	}
+}
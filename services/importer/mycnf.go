@@ -0,0 +1,88 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	config "gosuite/services/config"
+)
+
+// unquote strips the quotes option files allow around values.
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// FromMyCnf reads the [client] section of a MySQL option file, and the
+// [client<suffix>] sections picked with --defaults-group-suffix.
+func FromMyCnf(path string) ([]Candidate, error) {
+	data, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []Candidate{}
+	var current *Candidate
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			current = nil
+
+			section := strings.TrimSpace(line[1 : len(line)-1])
+			if !strings.HasPrefix(section, "client") {
+				continue
+			}
+
+			name := "my-cnf"
+			if suffix := slug(strings.Trim(strings.TrimPrefix(section, "client"), "_-")); suffix != "" {
+				name += "-" + suffix
+			}
+
+			candidates = append(candidates, Candidate{
+				Database: config.DatabaseConfig{Name: name},
+				Source:   fmt.Sprintf("%s: [%s]", filepath.Base(path), section),
+			})
+			current = &candidates[len(candidates)-1]
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		key, value, _ := strings.Cut(line, "=")
+		key = strings.ReplaceAll(strings.TrimSpace(key), "_", "-")
+		value = unquote(strings.TrimSpace(value))
+
+		switch key {
+		case "user":
+			current.Database.User = value
+		case "password":
+			current.Database.Password = value
+		case "host":
+			current.Database.Host = value
+		case "port":
+			current.Database.Port, _ = strconv.Atoi(value)
+		case "database":
+			current.Database.Database = value
+		case "ssl-mode":
+			if strings.EqualFold(value, "required") || strings.EqualFold(value, "verify_identity") {
+				current.Database.TLS = "true"
+			}
+		}
+	}
+
+	return candidates, scanner.Err()
+}
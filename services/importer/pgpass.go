@@ -0,0 +1,75 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	config "gosuite/services/config"
+)
+
+// splitPgpass splits a .pgpass line on the colons that aren't escaped.
+func splitPgpass(line string) []string {
+	fields := []string{}
+	var field strings.Builder
+
+	for idx := 0; idx < len(line); idx++ {
+		switch {
+		case line[idx] == '\\' && idx+1 < len(line):
+			idx++
+			field.WriteByte(line[idx])
+		case line[idx] == ':':
+			fields = append(fields, field.String())
+			field.Reset()
+		default:
+			field.WriteByte(line[idx])
+		}
+	}
+
+	return append(fields, field.String())
+}
+
+// FromPgpass lists the entries of a .pgpass file. They're Postgres, so
+// they're only shown, not added.
+func FromPgpass(path string) ([]Candidate, error) {
+	data, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []Candidate{}
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := splitPgpass(line)
+		if len(fields) != 5 {
+			continue
+		}
+
+		db := config.DatabaseConfig{
+			Host:     fields[0],
+			User:     fields[3],
+			Password: fields[4],
+		}
+		db.Port, _ = strconv.Atoi(fields[1])
+		if fields[2] != "*" {
+			db.Database = fields[2]
+		}
+		db.Name = slug("pg " + fields[0] + " " + db.Database)
+
+		candidates = append(candidates, Candidate{
+			Database:    db,
+			Source:      fmt.Sprintf("%s:%d", filepath.Base(path), number),
+			Unsupported: "Postgres is not supported yet",
+		})
+	}
+
+	return candidates, scanner.Err()
+}
@@ -1,11 +1,14 @@
 package tables
 
 import (
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	db "gosuite/db"
 	design "gosuite/design"
+	keymap "gosuite/keymap"
+	result "gosuite/result"
 )
 
 type Model struct {
@@ -26,28 +29,63 @@ func (m Model) Init() tea.Cmd {
 
 func (m Model) Update(msg tea.Msg, active bool, conn *db.Connection) (Model, tea.Cmd) {
 	var cmds []tea.Cmd
-	// var cmd tea.Cmd
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case db.ConnectionSuccess:
+		cmd = db.GetTablesCmd(msg.GetConnection())
+		cmds = append(cmds, cmd)
+
+	case db.ConnectionPending:
+		m.Tables = []string{}
+		m.SelectedTableIndex = 0
+
+	case db.TablesMsg:
+		if msg.Err == nil {
+			m.Tables = msg.Tables
+			m.SelectedTableIndex = 0
+		}
+
+	case design.MouseMsg:
+		last := max(len(m.Tables)-1, 0)
+
+		if scroll := msg.Scroll(); scroll != 0 {
+			m.SelectedTableIndex = min(max(m.SelectedTableIndex+scroll, 0), last)
+		}
+
+		if _, y, ok := msg.Content(); ok && msg.Clicked() {
+			_, visibleRows := design.ContentSize(msg.Width, msg.Height)
+			if idx := design.ScrollStart(m.SelectedTableIndex, visibleRows) + y; idx <= last && len(m.Tables) > 0 {
+				m.SelectedTableIndex = idx
+			}
+		}
+	}
 
 	if active {
+		keys := keymap.Keys.Tables
+
 		switch msg := msg.(type) {
 		case tea.KeyMsg:
-			switch msg.String() {
-			case "up":
+			switch {
+			case key.Matches(msg, keys.Up):
 				if m.SelectedTableIndex > 0 && active {
 					m.SelectedTableIndex--
 				}
-			case "down":
+			case key.Matches(msg, keys.Down):
 				if m.SelectedTableIndex < len(m.Tables)-1 && active {
 					m.SelectedTableIndex++
 				}
-				// TODO: Resurrect this
-				// case "enter":
-				// 	cmd = db.ExucuteSQLCmd("SELECT * FROM "+m.Tables[m.SelectedTableIndex]+" LIMIT 10", conn)
-				// 	cmds = append(cmds, cmd)
-				//
-				// case "i":
-				// 	cmd = db.ExucuteSQLCmd("DESCRIBE "+m.Tables[m.SelectedTableIndex], conn)
-				// 	cmds = append(cmds, cmd)
+			case key.Matches(msg, keys.Open):
+				if len(m.Tables) > 0 {
+					cmd = result.OpenTable(m.Tables[m.SelectedTableIndex])
+					cmds = append(cmds, cmd)
+				}
+
+			case key.Matches(msg, keys.Describe):
+				if len(m.Tables) > 0 {
+					cmd = db.RequestExecute("DESCRIBE " + m.Tables[m.SelectedTableIndex])
+					cmds = append(cmds, cmd)
+				}
 			}
 		}
 	}
@@ -60,15 +98,28 @@ func (m Model) View(selected bool, width int, height int) string {
 
 	tables := make([]string, 0)
 
-	for idx, table := range m.Tables {
+	_, visibleRows := design.ContentSize(width, height)
+	start := design.ScrollStart(m.SelectedTableIndex, visibleRows)
+
+	for idx := start; idx < len(m.Tables) && idx-start < visibleRows; idx++ {
 		tables = append(
 			tables,
 			tableStyles.Foreground(design.GetBorderColor(m.SelectedTableIndex == idx && selected)).
-				Render(table),
+				Render(m.Tables[idx]),
 		)
 	}
 
-	content := lipgloss.NewStyle().MaxHeight(height - 15).Render(lipgloss.JoinVertical(lipgloss.Left, tables...))
+	content := lipgloss.JoinVertical(lipgloss.Left, tables...)
+
+	return design.Pane{Index: 2, Title: "Tables", Selected: selected}.Render(width, height, content)
+}
+
+func (m Model) ShortHelp() []key.Binding {
+	keys := keymap.Keys.Tables
+	return []key.Binding{keys.Up, keys.Down, keys.Open, keys.Describe}
+}
 
-	return design.CreatePane(2, "Tables", selected, width, height, content)
+func (m Model) FullHelp() [][]key.Binding {
+	keys := keymap.Keys.Tables
+	return [][]key.Binding{{keys.Up, keys.Down}, {keys.Open, keys.Describe}}
 }
@@ -0,0 +1,268 @@
+package theme
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+
+	config "gosuite/services/config"
+)
+
+// Palette assigns a colour to every role the panes draw with.
+type Palette struct {
+	Border                   lipgloss.Color
+	BorderSelected           lipgloss.Color
+	ProductionBorder         lipgloss.Color
+	ProductionBorderSelected lipgloss.Color
+
+	Text    lipgloss.Color
+	Muted   lipgloss.Color
+	Accent  lipgloss.Color
+	Info    lipgloss.Color
+	Warning lipgloss.Color
+	Error   lipgloss.Color
+
+	Header              lipgloss.Color
+	SelectionForeground lipgloss.Color
+	SelectionBackground lipgloss.Color
+
+	Added   lipgloss.Color
+	Removed lipgloss.Color
+	Changed lipgloss.Color
+
+	Keyword    lipgloss.Color
+	String     lipgloss.Color
+	Comment    lipgloss.Color
+	Function   lipgloss.Color
+	Operator   lipgloss.Color
+	Identifier lipgloss.Color
+}
+
+func Dark() Palette {
+	return Palette{
+		Border:                   "255",
+		BorderSelected:           "50",
+		ProductionBorder:         "124",
+		ProductionBorderSelected: "196",
+
+		Text:    "252",
+		Muted:   "240",
+		Accent:  "50",
+		Info:    "87",
+		Warning: "214",
+		Error:   "196",
+
+		Header:              "238",
+		SelectionForeground: "#000000",
+		SelectionBackground: "#ffffff",
+
+		Added:   "42",
+		Removed: "196",
+		Changed: "214",
+
+		Keyword:    "140",
+		String:     "11",
+		Comment:    "240",
+		Function:   "198",
+		Operator:   "198",
+		Identifier: "87",
+	}
+}
+
+func Light() Palette {
+	return Palette{
+		Border:                   "244",
+		BorderSelected:           "30",
+		ProductionBorder:         "88",
+		ProductionBorderSelected: "160",
+
+		Text:    "235",
+		Muted:   "245",
+		Accent:  "30",
+		Info:    "25",
+		Warning: "166",
+		Error:   "160",
+
+		Header:              "253",
+		SelectionForeground: "#ffffff",
+		SelectionBackground: "#000000",
+
+		Added:   "28",
+		Removed: "160",
+		Changed: "166",
+
+		Keyword:    "91",
+		String:     "130",
+		Comment:    "245",
+		Function:   "161",
+		Operator:   "161",
+		Identifier: "25",
+	}
+}
+
+// HighContrast sticks to the 16 basic colours, which every terminal
+// renders at full brightness.
+func HighContrast() Palette {
+	return Palette{
+		Border:                   "15",
+		BorderSelected:           "11",
+		ProductionBorder:         "1",
+		ProductionBorderSelected: "9",
+
+		Text:    "15",
+		Muted:   "7",
+		Accent:  "11",
+		Info:    "14",
+		Warning: "11",
+		Error:   "9",
+
+		Header:              "4",
+		SelectionForeground: "0",
+		SelectionBackground: "11",
+
+		Added:   "10",
+		Removed: "9",
+		Changed: "11",
+
+		Keyword:    "13",
+		String:     "10",
+		Comment:    "7",
+		Function:   "14",
+		Operator:   "15",
+		Identifier: "14",
+	}
+}
+
+func Solarized() Palette {
+	return Palette{
+		Border:                   "#586e75",
+		BorderSelected:           "#2aa198",
+		ProductionBorder:         "#cb4b16",
+		ProductionBorderSelected: "#dc322f",
+
+		Text:    "#93a1a1",
+		Muted:   "#586e75",
+		Accent:  "#2aa198",
+		Info:    "#268bd2",
+		Warning: "#b58900",
+		Error:   "#dc322f",
+
+		Header:              "#073642",
+		SelectionForeground: "#002b36",
+		SelectionBackground: "#93a1a1",
+
+		Added:   "#859900",
+		Removed: "#dc322f",
+		Changed: "#b58900",
+
+		Keyword:    "#859900",
+		String:     "#2aa198",
+		Comment:    "#586e75",
+		Function:   "#268bd2",
+		Operator:   "#cb4b16",
+		Identifier: "#6c71c4",
+	}
+}
+
+var palettes = map[string]func() Palette{
+	"dark":          Dark,
+	"light":         Light,
+	"high-contrast": HighContrast,
+	"solarized":     Solarized,
+}
+
+// Names lists the palettes that can be chosen in the theme config.
+func Names() []string {
+	names := make([]string, 0, len(palettes))
+	for name := range palettes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// registry names every role for config overrides.
+func (p *Palette) registry() map[string]*lipgloss.Color {
+	return map[string]*lipgloss.Color{
+		"border":                     &p.Border,
+		"border_selected":            &p.BorderSelected,
+		"production_border":          &p.ProductionBorder,
+		"production_border_selected": &p.ProductionBorderSelected,
+
+		"text":    &p.Text,
+		"muted":   &p.Muted,
+		"accent":  &p.Accent,
+		"info":    &p.Info,
+		"warning": &p.Warning,
+		"error":   &p.Error,
+
+		"header":               &p.Header,
+		"selection_foreground": &p.SelectionForeground,
+		"selection_background": &p.SelectionBackground,
+
+		"added":   &p.Added,
+		"removed": &p.Removed,
+		"changed": &p.Changed,
+
+		"keyword":    &p.Keyword,
+		"string":     &p.String,
+		"comment":    &p.Comment,
+		"function":   &p.Function,
+		"operator":   &p.Operator,
+		"identifier": &p.Identifier,
+	}
+}
+
+// Detect picks the dark or light palette from the terminal background. It
+// queries the terminal, so call it before the program takes over stdin.
+func Detect() string {
+	if lipgloss.HasDarkBackground() {
+		return "dark"
+	}
+	return "light"
+}
+
+// Load builds the palette from the named theme and the per-role overrides.
+// An empty or "auto" name follows the terminal background.
+func Load(cfg config.ThemeConfig) (Palette, error) {
+	name := cfg.Name
+	if name == "" || name == "auto" {
+		name = Detect()
+	}
+
+	palette, ok := palettes[name]
+	if !ok {
+		return Dark(), fmt.Errorf("unknown theme %q", cfg.Name)
+	}
+
+	p := palette()
+	registry := p.registry()
+
+	for role, color := range cfg.Colors {
+		c, ok := registry[role]
+		if !ok {
+			return p, fmt.Errorf("unknown theme colour %q", role)
+		}
+		if color == "" {
+			return p, fmt.Errorf("theme colour %q is empty", role)
+		}
+		*c = lipgloss.Color(color)
+	}
+
+	return p, nil
+}
+
+// Current is the active palette used by every pane.
+var Current = Dark()
+
+// Style returns a new style drawn in the given colour.
+func Style(color lipgloss.Color) lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(color)
+}
+
+// Selection is the style of the row or cell under the cursor.
+func Selection() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(Current.SelectionForeground).Background(Current.SelectionBackground)
+}
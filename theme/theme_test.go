@@ -0,0 +1,62 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	config "gosuite/services/config"
+)
+
+func TestLoadPalette(t *testing.T) {
+	palette, err := Load(config.ThemeConfig{Name: "light"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if palette != Light() {
+		t.Errorf("Expected the light palette, got %v", palette)
+	}
+}
+
+func TestLoadOverrides(t *testing.T) {
+	palette, err := Load(config.ThemeConfig{
+		Name:   "solarized",
+		Colors: map[string]string{"border": "#ff00ff", "keyword": "200"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if palette.Border != lipgloss.Color("#ff00ff") {
+		t.Errorf("Expected #ff00ff, got %v", palette.Border)
+	}
+	if palette.Keyword != lipgloss.Color("200") {
+		t.Errorf("Expected 200, got %v", palette.Keyword)
+	}
+	if palette.Text != Solarized().Text {
+		t.Errorf("Expected %v, got %v", Solarized().Text, palette.Text)
+	}
+}
+
+func TestLoadRejectsUnknownNames(t *testing.T) {
+	if _, err := Load(config.ThemeConfig{Name: "neon"}); err == nil {
+		t.Errorf("Expected an error for an unknown theme")
+	}
+
+	if _, err := Load(config.ThemeConfig{Name: "dark", Colors: map[string]string{"borders": "1"}}); err == nil {
+		t.Errorf("Expected an error for an unknown colour role")
+	}
+}
+
+func TestPalettesSetEveryRole(t *testing.T) {
+	for _, name := range Names() {
+		palette := palettes[name]()
+
+		for role, color := range palette.registry() {
+			if *color == "" {
+				t.Errorf("Expected %s to set %s", name, role)
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package confirm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	keymap "gosuite/keymap"
+	theme "gosuite/theme"
+)
+
+func dialogStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Current.Error).
+		Padding(1, 2)
+}
+
+func titleStyle() lipgloss.Style { return theme.Style(theme.Current.Error).Bold(true) }
+func hintStyle() lipgloss.Style  { return theme.Style(theme.Current.Muted) }
+
+// Model is a modal dialog that runs a command once the user confirms it.
+// When Expect is set the user has to type it exactly, otherwise a single
+// "y" is enough.
+type Model struct {
+	Title   string
+	Details []string
+	Expect  string
+	Input   textinput.Model
+
+	onConfirm tea.Cmd
+	active    bool
+	mismatch  bool
+}
+
+func New(title string, details []string, expect string, onConfirm tea.Cmd) Model {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = expect
+	input.Focus()
+
+	return Model{
+		Title:     title,
+		Details:   details,
+		Expect:    expect,
+		Input:     input,
+		onConfirm: onConfirm,
+		active:    true,
+	}
+}
+
+func (m Model) Active() bool {
+	return m.active
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.active {
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	keys := keymap.Keys.Dialog
+
+	if key.Matches(keyMsg, keys.Cancel) {
+		m.active = false
+		return m, nil
+	}
+
+	if m.Expect == "" {
+		switch {
+		case key.Matches(keyMsg, keys.Accept):
+			m.active = false
+			return m, m.onConfirm
+		case key.Matches(keyMsg, keys.Decline):
+			m.active = false
+		}
+		return m, nil
+	}
+
+	if key.Matches(keyMsg, keys.Submit) {
+		if strings.TrimSpace(m.Input.Value()) != m.Expect {
+			m.mismatch = true
+			return m, nil
+		}
+		m.active = false
+		return m, m.onConfirm
+	}
+
+	var cmd tea.Cmd
+	m.mismatch = false
+	m.Input, cmd = m.Input.Update(msg)
+
+	return m, cmd
+}
+
+func (m Model) View(width int, height int) string {
+	lines := []string{titleStyle().Render(m.Title), ""}
+	lines = append(lines, m.Details...)
+	lines = append(lines, "")
+
+	if m.Expect == "" {
+		lines = append(lines, hintStyle().Render(fmt.Sprintf(
+			"%s to confirm, %s or %s to cancel",
+			keymap.Keys.Dialog.Accept.Help().Key,
+			keymap.Keys.Dialog.Decline.Help().Key,
+			keymap.Keys.Dialog.Cancel.Help().Key,
+		)))
+	} else {
+		lines = append(lines,
+			"Type "+titleStyle().Render(m.Expect)+" to confirm:",
+			m.Input.View(),
+		)
+		if m.mismatch {
+			lines = append(lines, titleStyle().Render("That does not match, try again"))
+		}
+		lines = append(lines, hintStyle().Render(fmt.Sprintf(
+			"%s to confirm, %s to cancel",
+			keymap.Keys.Dialog.Submit.Help().Key,
+			keymap.Keys.Dialog.Cancel.Help().Key,
+		)))
+	}
+
+	dialog := dialogStyle().Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, dialog)
+}
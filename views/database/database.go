@@ -1,46 +1,400 @@
 package database
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	db "gosuite/db"
 	design "gosuite/design"
+	keymap "gosuite/keymap"
+	config "gosuite/services/config"
+	importer "gosuite/services/importer"
+	theme "gosuite/theme"
 )
 
 type Model struct {
 	conn *db.Connection
+	// latency is of the last keepalive ping of conn.
+	latency time.Duration
+
+	databases []config.DatabaseConfig
+	invalid   []config.InvalidDatabase
+	warnings  config.Problems
+	cursor    int
+
+	// files are the config files, path the one new connections are
+	// added to.
+	files  []string
+	path   string
+	form   Form
+	status string
+
+	found    []importer.Candidate
+	importer importList
 }
 
-func InitModel() Model {
-	return Model{}
+// DeleteRequestMsg asks for the named connection to be deleted from the
+// config file at Path once the user confirms it.
+type DeleteRequestMsg struct {
+	Name string
+	Path string
+}
+
+func InitModel(cfg *config.AppConfig) Model {
+	m := Model{
+		databases: cfg.Databases,
+		invalid:   cfg.Invalid,
+		files:     cfg.Files,
+		path:      cfg.Path,
+	}
+
+	// Problems outside of a connection, such as a typo'd section, are shown
+	// below the list.
+	inDatabase := make(map[config.Problem]bool)
+	for _, invalid := range cfg.Invalid {
+		for _, problem := range invalid.Problems {
+			inDatabase[problem] = true
+		}
+	}
+	for _, problem := range cfg.Problems {
+		if !inDatabase[problem] {
+			m.warnings = append(m.warnings, problem)
+		}
+	}
+
+	return m
 }
 
 func (m Model) Init() tea.Cmd {
 	return nil
 }
 
-func (m Model) Update(msg tea.Msg, active bool) (Model, tea.Cmd) {
+// connect closes the current connection and opens the selected one.
+func (m Model) connect(cfg *config.DatabaseConfig) tea.Cmd {
+	if m.conn != nil {
+		if sqlDB := (*m.conn).GetConnection(); sqlDB != nil {
+			sqlDB.Close()
+		}
+	}
+
+	pending := db.ConnectionPending{Config: cfg}
+	return tea.Batch(
+		func() tea.Msg { return pending },
+		db.ConnectCmd(cfg),
+	)
+}
+
+// Reload lists the connections of cfg, reconnecting the current one if its
+// settings changed and disconnecting it if it's gone.
+func (m Model) Reload(cfg *config.AppConfig) (Model, tea.Cmd) {
+	reloaded := InitModel(cfg)
+	reloaded.conn = m.conn
+	reloaded.form = m.form
+	reloaded.found = m.found
+	reloaded.importer = m.importer
+	reloaded.cursor = min(m.cursor, max(len(cfg.Databases)+len(cfg.Invalid)-1, 0))
+
+	current := m.current()
+	if current == "" {
+		return reloaded, nil
+	}
+
+	for idx := range reloaded.databases {
+		if reloaded.databases[idx].Name != current {
+			continue
+		}
+		if reloaded.databases[idx] == *(*m.conn).GetConfig() {
+			return reloaded, nil
+		}
+		return reloaded, reloaded.connect(&reloaded.databases[idx])
+	}
+
+	if sqlDB := (*m.conn).GetConnection(); sqlDB != nil {
+		sqlDB.Close()
+	}
+	return reloaded, func() tea.Msg { return db.ConnectionPending{} }
+}
+
+// Editing is true while the connection form or the import list is open.
+func (m Model) Editing() bool {
+	return m.form.Active() || m.importer.active
+}
+
+// DeleteCmd removes the named connection from the config file at path.
+func DeleteCmd(path string, name string) tea.Cmd {
+	return func() tea.Msg {
+		return SavedMsg{Name: name, Path: path, Err: config.DeleteDatabase(path, name)}
+	}
+}
+
+// selectedName is the name of the connection under the cursor.
+func (m Model) selectedName() (string, bool) {
+	if m.cursor < len(m.databases) {
+		return m.databases[m.cursor].Name, true
+	}
+	if idx := m.cursor - len(m.databases); idx < len(m.invalid) {
+		return m.invalid[idx].Name, true
+	}
+	return "", false
+}
+
+// taken lists the connection names except skip.
+func (m Model) taken(skip string) []string {
+	names := []string{}
+	for _, database := range m.databases {
+		if database.Name != skip {
+			names = append(names, database.Name)
+		}
+	}
+	for _, invalid := range m.invalid {
+		if invalid.Name != skip {
+			names = append(names, invalid.Name)
+		}
+	}
+	return names
+}
+
+// updateConfig handles the keys that change the connections in the
+// config.
+func (m Model) updateConfig(msg tea.KeyMsg) (Model, tea.Cmd) {
+	keys := keymap.Keys.Database
+
+	switch {
+	case key.Matches(msg, keys.Import):
+		if len(m.found) == 0 {
+			m.status = "No connections found to import"
+			return m, nil
+		}
+		m.importer = importList{active: true}
+		return m, nil
+
+	case key.Matches(msg, keys.Add):
+		m.form = newForm("New connection", m.path, "", config.DatabaseConfig{}, m.taken(""))
+		return m, textinput.Blink
+
+	case key.Matches(msg, keys.Edit, keys.Duplicate, keys.Delete):
+		name, ok := m.selectedName()
+		if !ok {
+			return m, nil
+		}
+
+		path, info, err := config.FindDatabase(m.files, name)
+		if err != nil {
+			m.status = err.Error()
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, keys.Edit):
+			m.form = newForm("Edit "+name, path, name, info, m.taken(name))
+		case key.Matches(msg, keys.Duplicate):
+			info.Name += "-copy"
+			m.form = newForm("Duplicate "+name, m.path, "", info, m.taken(""))
+		default:
+			return m, func() tea.Msg { return DeleteRequestMsg{Name: name, Path: path} }
+		}
+		return m, textinput.Blink
+	}
+
 	return m, nil
 }
 
+func (m Model) Update(msg tea.Msg, active bool) (Model, tea.Cmd) {
+	rows := len(m.databases) + len(m.invalid)
+
+	if m.importer.active {
+		if _, ok := msg.(tea.KeyMsg); ok {
+			return m.updateImport(msg)
+		}
+	}
+
+	// The open form takes the keys and its own results, anything else
+	// still updates the list behind it.
+	var formCmd tea.Cmd
+	if m.form.Active() {
+		m.form, formCmd = m.form.Update(msg)
+
+		switch msg.(type) {
+		case tea.KeyMsg, SavedMsg, testedMsg:
+			return m, formCmd
+		}
+	}
+
+	switch msg := msg.(type) {
+	case DiscoveredMsg:
+		m.found = msg.Candidates
+
+	case SavedMsg:
+		m.status = ""
+		if msg.Err != nil {
+			m.status = msg.Err.Error()
+		}
+
+	case db.Connection:
+		m.conn = &msg
+		m.latency = 0
+
+	case db.PingMsg:
+		if msg.Err == nil && msg.DB != nil && m.conn != nil && msg.DB == (*m.conn).GetConnection() {
+			m.latency = msg.Latency
+		}
+
+	case design.MouseMsg:
+		last := max(rows-1, 0)
+
+		if scroll := msg.Scroll(); scroll != 0 {
+			m.cursor = min(max(m.cursor+scroll, 0), last)
+		}
+
+		if _, y, ok := msg.Content(); ok && msg.Clicked() {
+			_, visibleRows := design.ContentSize(msg.Width, msg.Height)
+			if idx := design.ScrollStart(m.cursor, visibleRows) + y; idx < rows {
+				m.cursor = idx
+			}
+		}
+
+	case tea.KeyMsg:
+		if !active {
+			return m, nil
+		}
+
+		m.status = ""
+		keys := keymap.Keys.Database
+
+		switch {
+		case key.Matches(msg, keys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case key.Matches(msg, keys.Down):
+			if m.cursor < rows-1 {
+				m.cursor++
+			}
+
+		case key.Matches(msg, keys.Connect):
+			if m.cursor < len(m.databases) {
+				return m, m.connect(&m.databases[m.cursor])
+			}
+
+		case key.Matches(msg, keys.Reconnect):
+			if m.conn == nil || (*m.conn).GetConfig() == nil {
+				return m, nil
+			}
+			return m, m.connect((*m.conn).GetConfig())
+
+		default:
+			return m.updateConfig(msg)
+		}
+	}
+
+	return m, formCmd
+}
+
+// formatLatency keeps a tenth of a millisecond for fast pings.
+func formatLatency(latency time.Duration) string {
+	if latency < 100*time.Microsecond {
+		return "<0.1ms"
+	}
+	if latency < 10*time.Millisecond {
+		return latency.Round(100 * time.Microsecond).String()
+	}
+	return latency.Round(time.Millisecond).String()
+}
+
+// current is the name of the connection in use.
+func (m Model) current() string {
+	if m.conn == nil || (*m.conn).GetConfig() == nil {
+		return ""
+	}
+	return (*m.conn).GetConfig().Name
+}
+
 func (m Model) View(selected bool, width int, height int) string {
-	content := ""
-
-	if m.conn == nil {
-		content = "No connection"
-	} else {
-		content = (*m.conn).Status()
-	}
-
-	return design.CreatePane(
-		1,
-		"Database",
-		selected,
-		width,
-		height,
-		lipgloss.JoinHorizontal(lipgloss.Left,
-			lipgloss.NewStyle().Render(content)),
-	)
+	muted := theme.Style(theme.Current.Muted)
+	errorStyle := theme.Style(theme.Current.Error)
+
+	lines := make([]string, 0, len(m.databases)+len(m.invalid)+len(m.warnings))
 
+	for idx, database := range m.databases {
+		style := lipgloss.NewStyle().Foreground(design.GetBorderColor(m.cursor == idx && selected))
+		line := style.Render(database.Name)
+
+		if database.Name == m.current() {
+			status := (*m.conn).Status()
+			if m.latency > 0 {
+				status += " " + formatLatency(m.latency)
+			}
+			line += " " + muted.Render(status)
+		}
+		if database.ReadOnly {
+			line += " " + muted.Render("read-only")
+		}
+
+		lines = append(lines, line)
+	}
+
+	for idx, invalid := range m.invalid {
+		line := invalid.Name
+		if len(invalid.Problems) > 0 {
+			line += ": " + invalid.Problems[0].Message
+		}
+
+		style := errorStyle
+		if m.cursor == len(m.databases)+idx && selected {
+			style = style.Underline(true)
+		}
+		lines = append(lines, style.Render(line))
+	}
+
+	for _, warning := range m.warnings {
+		lines = append(lines, theme.Style(theme.Current.Warning).Render(warning.Message))
+	}
+
+	if count := m.importable(); count > 0 {
+		lines = append(lines, muted.Render(fmt.Sprintf("%d found, %s to import", count, keymap.Keys.Database.Import.Help().Key)))
+	}
+
+	if m.status != "" {
+		lines = append(lines, errorStyle.Render(m.status))
+	}
+
+	if len(lines) == 0 {
+		lines = append(lines, muted.Render(fmt.Sprintf("No connections, %s to add one", keymap.Keys.Database.Add.Help().Key)))
+	}
+
+	_, visibleRows := design.ContentSize(width, height)
+	start := min(design.ScrollStart(m.cursor, visibleRows), len(lines))
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines[start:]...)
+
+	return design.Pane{Index: 1, Title: "Database", Selected: selected}.Render(width, height, content)
+}
+
+// EditorView draws the open connection form or import list over the whole
+// screen.
+func (m Model) EditorView(width int, height int) string {
+	if m.importer.active {
+		return m.importView(width, height)
+	}
+	return m.form.View(width, height)
+}
+
+func (m Model) ShortHelp() []key.Binding {
+	keys := keymap.Keys.Database
+	return []key.Binding{keys.Up, keys.Down, keys.Connect, keys.Add, keys.Edit}
+}
+
+func (m Model) FullHelp() [][]key.Binding {
+	keys := keymap.Keys.Database
+	return [][]key.Binding{
+		{keys.Up, keys.Down},
+		{keys.Connect, keys.Reconnect},
+		{keys.Add, keys.Edit, keys.Duplicate, keys.Delete, keys.Import},
+	}
 }
@@ -0,0 +1,131 @@
+package database
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	design "gosuite/design"
+	keymap "gosuite/keymap"
+	importer "gosuite/services/importer"
+	theme "gosuite/theme"
+)
+
+// DiscoveredMsg carries the connections found by DiscoverCmd.
+type DiscoveredMsg struct {
+	Candidates []importer.Candidate
+}
+
+// DiscoverCmd looks for connections to import in the working and home
+// directories.
+func DiscoverCmd() tea.Cmd {
+	return func() tea.Msg {
+		dir, _ := os.Getwd()
+		home, _ := os.UserHomeDir()
+		return DiscoveredMsg{Candidates: importer.Discover(dir, home)}
+	}
+}
+
+// importList is the dialog listing the connections found to import.
+type importList struct {
+	cursor int
+	active bool
+}
+
+// added is true when the candidate is already in the config.
+func (m Model) added(candidate importer.Candidate) bool {
+	for _, database := range m.databases {
+		if candidate.Same(database) {
+			return true
+		}
+	}
+	return false
+}
+
+// importable counts the candidates that could be added.
+func (m Model) importable() int {
+	count := 0
+	for _, candidate := range m.found {
+		if candidate.Supported() && !m.added(candidate) {
+			count++
+		}
+	}
+	return count
+}
+
+func (m Model) updateImport(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	keys := keymap.Keys.Database
+
+	switch {
+	case key.Matches(keyMsg, keymap.Keys.Dialog.Cancel):
+		m.importer.active = false
+
+	case key.Matches(keyMsg, keys.Up):
+		if m.importer.cursor > 0 {
+			m.importer.cursor--
+		}
+
+	case key.Matches(keyMsg, keys.Down):
+		if m.importer.cursor < len(m.found)-1 {
+			m.importer.cursor++
+		}
+
+	case key.Matches(keyMsg, keymap.Keys.Dialog.Submit):
+		candidate := m.found[m.importer.cursor]
+		if !candidate.Supported() || m.added(candidate) {
+			return m, nil
+		}
+
+		m.importer.active = false
+		m.form = newForm("Import from "+candidate.Source, m.path, "", candidate.Database, m.taken(""))
+		return m, textinput.Blink
+	}
+
+	return m, nil
+}
+
+func (m Model) importView(width int, height int) string {
+	muted := theme.Style(theme.Current.Muted)
+
+	lines := []string{theme.Style(theme.Current.Accent).Bold(true).Render("Import connections"), ""}
+
+	for idx, candidate := range m.found {
+		note := ""
+		switch {
+		case !candidate.Supported():
+			note = candidate.Unsupported
+		case m.added(candidate):
+			note = "already added"
+		}
+
+		name := lipgloss.NewStyle().Foreground(design.GetBorderColor(idx == m.importer.cursor)).Width(20).Render(candidate.Database.Name)
+		line := name + muted.Render(candidate.Source)
+		if note != "" {
+			line += " " + theme.Style(theme.Current.Warning).Render(note)
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "", muted.Render(fmt.Sprintf(
+		"%s to review and add, %s to close",
+		keymap.Keys.Dialog.Submit.Help().Key,
+		keymap.Keys.Dialog.Cancel.Help().Key,
+	)))
+
+	dialog := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Current.BorderSelected).
+		Padding(1, 2).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, dialog)
+}
@@ -0,0 +1,182 @@
+package explain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	db "gosuite/db"
+	design "gosuite/design"
+	keymap "gosuite/keymap"
+	theme "gosuite/theme"
+)
+
+func fullScanStyle() lipgloss.Style { return theme.Style(theme.Current.Error).Bold(true) }
+func filesortStyle() lipgloss.Style { return theme.Style(theme.Current.Warning).Bold(true) }
+func detailStyle() lipgloss.Style   { return theme.Style(theme.Current.Muted) }
+func accessStyle() lipgloss.Style   { return theme.Style(theme.Current.Info) }
+func errorStyle() lipgloss.Style    { return theme.Style(theme.Current.Error) }
+
+type Model struct {
+	query   string
+	analyze bool
+	plan    *Node
+	err     error
+	offset  int
+}
+
+func InitModel() Model {
+	return Model{}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg, active bool) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case db.ExplainResult:
+		m.query = msg.Query
+		m.analyze = msg.Analyze
+		m.plan = nil
+		m.err = msg.Err
+		m.offset = 0
+
+		if msg.Err == nil {
+			if msg.Analyze {
+				m.plan, m.err = ParseAnalyze(msg.Output)
+			} else {
+				m.plan, m.err = ParseJSON(msg.Output)
+			}
+		}
+
+	case design.MouseMsg:
+		m.offset = max(m.offset+msg.Scroll(), 0)
+
+	case tea.KeyMsg:
+		if !active {
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, keymap.Keys.Explain.Up):
+			if m.offset > 0 {
+				m.offset--
+			}
+		case key.Matches(msg, keymap.Keys.Explain.Down):
+			m.offset++
+		}
+	}
+
+	return m, nil
+}
+
+func formatNumber(f float64) string {
+	if f == float64(int64(f)) {
+		return fmt.Sprintf("%d", int64(f))
+	}
+	return fmt.Sprintf("%.2f", f)
+}
+
+func renderNode(node *Node) string {
+	label := node.Label
+
+	switch {
+	case node.FullScan:
+		label = fullScanStyle().Render(label + " [FULL SCAN]")
+	case node.Filesort:
+		label = filesortStyle().Render(label + " [FILESORT]")
+	}
+
+	details := make([]string, 0)
+
+	if node.Access != "" {
+		details = append(details, "type="+accessStyle().Render(node.Access))
+	}
+	if node.Key != "" {
+		details = append(details, "key="+node.Key)
+	}
+	if node.HasActual {
+		details = append(details, fmt.Sprintf(
+			"rows=%s est / %s actual × %d",
+			formatNumber(node.EstimatedRows),
+			formatNumber(node.ActualRows),
+			node.Loops,
+		))
+	} else if node.EstimatedRows > 0 {
+		details = append(details, "rows="+formatNumber(node.EstimatedRows)+" est")
+	}
+	if node.Cost > 0 {
+		details = append(details, "cost="+formatNumber(node.Cost))
+	}
+
+	if len(details) == 0 {
+		return label
+	}
+
+	return label + "  " + detailStyle().Render(strings.Join(details, " "))
+}
+
+func renderTree(node *Node, prefix string, last bool, root bool, lines []string) []string {
+	branch := ""
+	childPrefix := prefix
+
+	if !root {
+		if last {
+			branch = "└─ "
+			childPrefix += "   "
+		} else {
+			branch = "├─ "
+			childPrefix += "│  "
+		}
+	}
+
+	lines = append(lines, detailStyle().Render(prefix+branch)+renderNode(node))
+
+	for idx, child := range node.Children {
+		lines = renderTree(child, childPrefix, idx == len(node.Children)-1, false, lines)
+	}
+
+	return lines
+}
+
+func (m Model) View(selected bool, width int, height int) string {
+	contentWidth, contentHeight := design.ContentSize(width, height)
+	content := "Press ctrl+x to explain the statement under the cursor..."
+
+	if m.err != nil {
+		content = errorStyle().Render(m.err.Error())
+	} else if m.plan != nil {
+		lines := renderTree(m.plan, "", true, true, make([]string, 0))
+
+		offset := m.offset
+		if offset > len(lines)-1 {
+			offset = len(lines) - 1
+		}
+
+		title := "EXPLAIN FORMAT=JSON"
+		if m.analyze {
+			title = "EXPLAIN ANALYZE"
+		}
+
+		content = lipgloss.JoinVertical(
+			lipgloss.Left,
+			detailStyle().Render(title+": "+m.query),
+			"",
+			lipgloss.NewStyle().MaxWidth(contentWidth).MaxHeight(max(contentHeight-2, 1)).Render(strings.Join(lines[offset:], "\n")),
+		)
+	}
+
+	return design.Pane{Index: 5, Title: "Explain", Selected: selected}.Render(width, height, content)
+}
+
+func (m Model) ShortHelp() []key.Binding {
+	return []key.Binding{keymap.Keys.Explain.Up, keymap.Keys.Explain.Down, keymap.Keys.Global.Explain}
+}
+
+func (m Model) FullHelp() [][]key.Binding {
+	return [][]key.Binding{m.ShortHelp()}
+}
@@ -0,0 +1,273 @@
+package explain
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Node struct {
+	Label  string
+	Table  string
+	Access string
+	Key    string
+
+	EstimatedRows float64
+	Cost          float64
+
+	// ActualRows and Loops are only known for EXPLAIN ANALYZE plans.
+	HasActual  bool
+	ActualRows float64
+	Loops      int
+
+	FullScan bool
+	Filesort bool
+
+	Children []*Node
+}
+
+var (
+	analyzeLineRegex = regexp.MustCompile(`^(\s*)-> (.*)$`)
+	costRegex        = regexp.MustCompile(`\s*\(cost=([\d.e+]+) rows=([\d.e+]+)\)`)
+	actualRegex      = regexp.MustCompile(`\s*\(actual time=[\d.e+]+\.\.[\d.e+]+ rows=([\d.e+]+) loops=(\d+)\)`)
+	neverRegex       = regexp.MustCompile(`\s*\(never executed\)`)
+	tableRegex       = regexp.MustCompile(`\bon (\S+)`)
+	keyRegex         = regexp.MustCompile(`\busing (\S+)`)
+)
+
+// accessTypes maps the operation names of the EXPLAIN ANALYZE tree to the
+// access types shown by traditional EXPLAIN.
+var accessTypes = []struct {
+	prefix string
+	access string
+}{
+	{"Single-row index lookup", "eq_ref"},
+	{"Single-row covering index lookup", "eq_ref"},
+	{"Covering index lookup", "ref"},
+	{"Index lookup", "ref"},
+	{"Covering index range scan", "range"},
+	{"Index range scan", "range"},
+	{"Covering index skip scan", "range"},
+	{"Index skip scan", "range"},
+	{"Covering index scan", "index"},
+	{"Index scan", "index"},
+	{"Table scan", "ALL"},
+	{"Full-text index search", "fulltext"},
+	{"Constant row", "const"},
+}
+
+func parseFloat(s string) float64 {
+	f, _ := strconv.ParseFloat(s, 64)
+	return f
+}
+
+func parseAnalyzeNode(line string) *Node {
+	node := &Node{}
+
+	if match := costRegex.FindStringSubmatch(line); match != nil {
+		node.Cost = parseFloat(match[1])
+		node.EstimatedRows = parseFloat(match[2])
+	}
+
+	if match := actualRegex.FindStringSubmatch(line); match != nil {
+		node.HasActual = true
+		node.ActualRows = parseFloat(match[1])
+		node.Loops, _ = strconv.Atoi(match[2])
+	} else if neverRegex.MatchString(line) {
+		node.HasActual = true
+	}
+
+	label := costRegex.ReplaceAllString(line, "")
+	label = actualRegex.ReplaceAllString(label, "")
+	label = neverRegex.ReplaceAllString(label, "")
+	node.Label = strings.TrimSpace(label)
+
+	for _, accessType := range accessTypes {
+		if strings.HasPrefix(node.Label, accessType.prefix) {
+			node.Access = accessType.access
+
+			if match := tableRegex.FindStringSubmatch(node.Label); match != nil {
+				node.Table = match[1]
+			}
+			if match := keyRegex.FindStringSubmatch(node.Label); match != nil {
+				node.Key = match[1]
+			}
+			break
+		}
+	}
+
+	node.FullScan = node.Access == "ALL"
+	node.Filesort = strings.HasPrefix(node.Label, "Sort")
+
+	return node
+}
+
+// ParseAnalyze parses the tree printed by EXPLAIN ANALYZE (and EXPLAIN
+// FORMAT=TREE), where each level is indented by four spaces.
+func ParseAnalyze(output string) (*Node, error) {
+	root := &Node{Label: "Query"}
+	stack := []*Node{root}
+	depths := []int{-1}
+
+	var last *Node
+
+	for _, line := range strings.Split(output, "\n") {
+		match := analyzeLineRegex.FindStringSubmatch(line)
+
+		if match == nil {
+			// Long conditions are sometimes wrapped onto the next line.
+			if last != nil && strings.TrimSpace(line) != "" {
+				last.Label += " " + strings.TrimSpace(line)
+			}
+			continue
+		}
+
+		depth := len(match[1])
+		node := parseAnalyzeNode(match[2])
+
+		for len(depths) > 1 && depths[len(depths)-1] >= depth {
+			stack = stack[:len(stack)-1]
+			depths = depths[:len(depths)-1]
+		}
+
+		parent := stack[len(stack)-1]
+		parent.Children = append(parent.Children, node)
+
+		stack = append(stack, node)
+		depths = append(depths, depth)
+		last = node
+	}
+
+	if len(root.Children) == 0 {
+		return nil, fmt.Errorf("no plan found in EXPLAIN ANALYZE output")
+	}
+
+	if len(root.Children) == 1 {
+		return root.Children[0], nil
+	}
+
+	return root, nil
+}
+
+func jsonFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case string:
+		return parseFloat(v)
+	}
+	return 0
+}
+
+func jsonString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return ""
+}
+
+func parseJSONTable(table map[string]interface{}) *Node {
+	node := &Node{
+		Table:         jsonString(table["table_name"]),
+		Access:        jsonString(table["access_type"]),
+		Key:           jsonString(table["key"]),
+		EstimatedRows: jsonFloat(table["rows_examined_per_scan"]),
+	}
+
+	if costInfo, ok := table["cost_info"].(map[string]interface{}); ok {
+		node.Cost = jsonFloat(costInfo["prefix_cost"])
+	}
+
+	node.Label = fmt.Sprintf("%s on %s", node.Access, node.Table)
+	if node.Key != "" {
+		node.Label += " using " + node.Key
+	}
+	node.FullScan = node.Access == "ALL"
+
+	node.Children = parseJSONChildren(table)
+
+	return node
+}
+
+func parseJSONChildren(object map[string]interface{}) []*Node {
+	children := make([]*Node, 0)
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		children = append(children, parseJSONValue(key, object[key])...)
+	}
+
+	return children
+}
+
+func parseJSONValue(key string, value interface{}) []*Node {
+	switch v := value.(type) {
+	case []interface{}:
+		nodes := make([]*Node, 0)
+		for _, item := range v {
+			nodes = append(nodes, parseJSONValue(key, item)...)
+		}
+		return nodes
+
+	case map[string]interface{}:
+		switch key {
+		case "table":
+			return []*Node{parseJSONTable(v)}
+
+		case "cost_info":
+			return nil
+
+		case "nested_loop", "query_specifications":
+			// Array items wrap a single operation, e.g. {"table": {...}}.
+			return parseJSONChildren(v)
+		}
+
+		node := &Node{Label: strings.ReplaceAll(key, "_", " ")}
+
+		if costInfo, ok := v["cost_info"].(map[string]interface{}); ok {
+			node.Cost = jsonFloat(costInfo["query_cost"]) + jsonFloat(costInfo["sort_cost"])
+		}
+		if message := jsonString(v["message"]); message != "" {
+			node.Label += ": " + message
+		}
+		if filesort, ok := v["using_filesort"].(bool); ok && filesort {
+			node.Filesort = true
+		}
+
+		node.Children = parseJSONChildren(v)
+
+		// Collapse wrappers that only hold a single operation.
+		if len(node.Children) == 1 && node.Cost == 0 && !node.Filesort && key != "query_block" {
+			return node.Children
+		}
+
+		return []*Node{node}
+	}
+
+	return nil
+}
+
+// ParseJSON parses the output of EXPLAIN FORMAT=JSON.
+func ParseJSON(output string) (*Node, error) {
+	var plan map[string]interface{}
+
+	if err := json.Unmarshal([]byte(output), &plan); err != nil {
+		return nil, err
+	}
+
+	nodes := parseJSONValue("plan", plan)
+
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no plan found in EXPLAIN output")
+	}
+
+	return nodes[0], nil
+}
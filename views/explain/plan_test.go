@@ -0,0 +1,74 @@
+package explain
+
+import "testing"
+
+const analyzeOutput = `-> Sort: posts.title  (cost=2.10 rows=3) (actual time=0.081..0.082 rows=3 loops=1)
+    -> Nested loop inner join  (cost=1.60 rows=3) (actual time=0.053..0.068 rows=3 loops=1)
+        -> Filter: (posts.author_id is not null)  (cost=0.55 rows=3) (actual time=0.030..0.036 rows=3 loops=1)
+            -> Table scan on posts  (cost=0.55 rows=3) (actual time=0.029..0.034 rows=3 loops=1)
+        -> Single-row index lookup on authors using PRIMARY (id=posts.author_id)  (cost=0.28 rows=1) (actual time=0.009..0.009 rows=1 loops=3)
+`
+
+func TestParseAnalyze(t *testing.T) {
+	plan, err := ParseAnalyze(analyzeOutput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !plan.Filesort || plan.Label != "Sort: posts.title" {
+		t.Errorf("Expected root to be a filesort, got %+v", plan)
+	}
+
+	join := plan.Children[0]
+	if len(join.Children) != 2 {
+		t.Fatalf("Expected join to have 2 children, got %d", len(join.Children))
+	}
+
+	scan := join.Children[0].Children[0]
+	if !scan.FullScan || scan.Table != "posts" || scan.ActualRows != 3 {
+		t.Errorf("Expected full scan on posts, got %+v", scan)
+	}
+
+	lookup := join.Children[1]
+	if lookup.Access != "eq_ref" || lookup.Key != "PRIMARY" || lookup.Loops != 3 || lookup.Cost != 0.28 {
+		t.Errorf("Expected eq_ref lookup using PRIMARY, got %+v", lookup)
+	}
+}
+
+const jsonOutput = `{
+  "query_block": {
+    "select_id": 1,
+    "cost_info": {"query_cost": "1.60"},
+    "ordering_operation": {
+      "using_filesort": true,
+      "nested_loop": [
+        {"table": {"table_name": "posts", "access_type": "ALL", "rows_examined_per_scan": 3, "cost_info": {"prefix_cost": "0.55"}}},
+        {"table": {"table_name": "authors", "access_type": "eq_ref", "key": "PRIMARY", "rows_examined_per_scan": 1, "cost_info": {"prefix_cost": "1.60"}}}
+      ]
+    }
+  }
+}`
+
+func TestParseJSON(t *testing.T) {
+	plan, err := ParseJSON(jsonOutput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if plan.Label != "query block" || plan.Cost != 1.6 {
+		t.Errorf("Expected query block costing 1.60, got %+v", plan)
+	}
+
+	ordering := plan.Children[0]
+	if !ordering.Filesort || len(ordering.Children) != 2 {
+		t.Fatalf("Expected filesort over 2 tables, got %+v", ordering)
+	}
+
+	if posts := ordering.Children[0]; !posts.FullScan || posts.Table != "posts" {
+		t.Errorf("Expected full scan on posts, got %+v", posts)
+	}
+
+	if authors := ordering.Children[1]; authors.Key != "PRIMARY" || authors.EstimatedRows != 1 {
+		t.Errorf("Expected PRIMARY lookup on authors, got %+v", authors)
+	}
+}
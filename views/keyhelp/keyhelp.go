@@ -0,0 +1,100 @@
+package keyhelp
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+
+	keymap "gosuite/keymap"
+	theme "gosuite/theme"
+)
+
+var sectionStyle = lipgloss.NewStyle().Padding(0, 2, 1, 0).Width(40)
+
+func titleStyle() lipgloss.Style { return theme.Style(theme.Current.Accent).Bold(true) }
+func keyStyle() lipgloss.Style   { return theme.Style(theme.Current.Info).Width(14) }
+func descStyle() lipgloss.Style  { return theme.Style(theme.Current.Text) }
+
+func overlayStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Current.Accent).
+		Padding(1, 2)
+}
+
+// Section is the help for one pane.
+type Section struct {
+	Title string
+	Keys  help.KeyMap
+}
+
+func renderSection(section Section) string {
+	lines := []string{titleStyle().Render(section.Title)}
+
+	for _, group := range section.Keys.FullHelp() {
+		for _, binding := range group {
+			if !binding.Enabled() {
+				continue
+			}
+			lines = append(lines, keyStyle().Render(binding.Help().Key)+descStyle().Render(binding.Help().Desc))
+		}
+	}
+
+	return sectionStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// lines lays the sections out in as many columns as fit width.
+func lines(sections []Section, width int) []string {
+	columns := (width - 6) / sectionStyle.GetWidth()
+	if columns < 1 {
+		columns = 1
+	}
+
+	rows := make([]string, 0)
+	for start := 0; start < len(sections); start += columns {
+		end := start + columns
+		if end > len(sections) {
+			end = len(sections)
+		}
+
+		blocks := make([]string, 0, columns)
+		for _, section := range sections[start:end] {
+			blocks = append(blocks, renderSection(section))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, blocks...))
+	}
+
+	return strings.Split(lipgloss.JoinVertical(lipgloss.Left, rows...), "\n")
+}
+
+// visibleLines is how many lines of sections fit above the close hint.
+func visibleLines(height int) int {
+	return max(height-overlayStyle().GetVerticalFrameSize()-1, 1)
+}
+
+// MaxOffset is how far the help scrolls down before its last line shows.
+func MaxOffset(sections []Section, width int, height int) int {
+	return max(len(lines(sections, width))-visibleLines(height), 0)
+}
+
+// View renders every section in as many columns as fit the terminal,
+// scrolled down by offset lines when they don't fit its height.
+func View(sections []Section, closeKey key.Binding, scroll keymap.ListKeys, width int, height int, offset int) string {
+	all := lines(sections, width)
+	visible := visibleLines(height)
+
+	offset = min(max(offset, 0), max(len(all)-visible, 0))
+	shown := all[offset:min(offset+visible, len(all))]
+
+	hint := "Press " + closeKey.Help().Key + " to close"
+	if len(shown) < len(all) {
+		hint += ", " + scroll.Up.Help().Key + "/" + scroll.Down.Help().Key + " to scroll"
+	}
+	shown = append(shown, descStyle().Render(hint))
+
+	overlay := overlayStyle().Render(strings.Join(shown, "\n"))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, overlay)
+}
@@ -0,0 +1,45 @@
+package keyhelp
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	keymap "gosuite/keymap"
+)
+
+func TestViewScrollsAtSmallHeight(t *testing.T) {
+	sections := make([]Section, 0, 6)
+	for idx := 1; idx <= 6; idx++ {
+		sections = append(sections, Section{Title: fmt.Sprintf("Pane %d", idx), Keys: keymap.Keys.Global})
+	}
+
+	keys := keymap.Keys
+	width, height := 50, 14
+
+	view := View(sections, keys.Global.Help, keys.Help, width, height, 0)
+
+	if lipgloss.Height(view) != height {
+		t.Errorf("Expected the help to fit %d lines, got %d", height, lipgloss.Height(view))
+	}
+	if !strings.Contains(view, "Pane 1") || strings.Contains(view, "Pane 6") || !strings.Contains(view, "to scroll") {
+		t.Errorf("Expected the first section and a scroll hint, got\n%s", view)
+	}
+
+	last := MaxOffset(sections, width, height)
+	if last == 0 {
+		t.Fatalf("Expected the sections not to fit in %d lines", height)
+	}
+
+	view = View(sections, keys.Global.Help, keys.Help, width, height, last+10)
+
+	if lipgloss.Height(view) != height || !strings.Contains(view, keys.Global.SaveLayout.Help().Desc) || strings.Contains(view, "Pane 1") {
+		t.Errorf("Expected to scroll to the last section, got\n%s", view)
+	}
+
+	if MaxOffset(sections[:1], width, 80) != 0 || strings.Contains(View(sections[:1], keys.Global.Help, keys.Help, width, 80, 0), "to scroll") {
+		t.Errorf("Expected no scrolling when the help fits")
+	}
+}
@@ -0,0 +1,401 @@
+package processlist
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	db "gosuite/db"
+	design "gosuite/design"
+	keymap "gosuite/keymap"
+	theme "gosuite/theme"
+)
+
+const DefaultInterval = 2 * time.Second
+
+func headerStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Bold(true).Background(theme.Current.Header)
+}
+func mutedStyle() lipgloss.Style { return theme.Style(theme.Current.Muted) }
+func errorStyle() lipgloss.Style { return theme.Style(theme.Current.Error) }
+
+type column struct {
+	title string
+	width int
+	value func(p db.Process) string
+	less  func(a, b db.Process) bool
+}
+
+var columns = []column{
+	{"Id", 8, func(p db.Process) string { return fmt.Sprint(p.ID) }, func(a, b db.Process) bool { return a.ID < b.ID }},
+	{"User", 12, func(p db.Process) string { return p.User }, func(a, b db.Process) bool { return a.User < b.User }},
+	{"Host", 20, func(p db.Process) string { return p.Host }, func(a, b db.Process) bool { return a.Host < b.Host }},
+	{"DB", 12, func(p db.Process) string { return p.DB }, func(a, b db.Process) bool { return a.DB < b.DB }},
+	{"Command", 10, func(p db.Process) string { return p.Command }, func(a, b db.Process) bool { return a.Command < b.Command }},
+	{"Time", 7, func(p db.Process) string { return fmt.Sprint(p.Time) }, func(a, b db.Process) bool { return a.Time < b.Time }},
+	{"State", 18, func(p db.Process) string { return p.State }, func(a, b db.Process) bool { return a.State < b.State }},
+	{"Info", 0, func(p db.Process) string { return p.Info }, func(a, b db.Process) bool { return a.Info < b.Info }},
+}
+
+const timeColumn = 5
+
+type tickMsg struct{}
+
+// KillRequestMsg asks for a session's query, or the whole session when
+// Connection is set, to be killed once the user has confirmed it.
+type KillRequestMsg struct {
+	Process    db.Process
+	Connection bool
+}
+
+type Model struct {
+	interval time.Duration
+
+	processes []db.Process
+	err       error
+	status    string
+	updatedAt time.Time
+
+	// cursor is the row of the selected process, which is followed by ID
+	// as the list is polled and re-sorted.
+	cursor     int
+	selected   int64
+	sortColumn int
+	sortDesc   bool
+
+	Filter    textinput.Model
+	filtering bool
+
+	visible bool
+	polling bool
+}
+
+func InitModel(interval time.Duration) Model {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	filter := textinput.New()
+	filter.Prompt = "Filter: "
+
+	return Model{
+		interval:   interval,
+		processes:  []db.Process{},
+		sortColumn: timeColumn,
+		sortDesc:   true,
+		Filter:     filter,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Filtering() bool {
+	return m.filtering
+}
+
+func (m Model) tick() tea.Cmd {
+	return tea.Tick(m.interval, func(time.Time) tea.Msg {
+		return tickMsg{}
+	})
+}
+
+// SetVisible starts polling when the pane is shown. Polling stops by itself
+// on the next tick after the pane is hidden.
+func (m Model) SetVisible(visible bool) (Model, tea.Cmd) {
+	m.visible = visible
+
+	if visible && !m.polling {
+		m.polling = true
+		return m, func() tea.Msg { return tickMsg{} }
+	}
+
+	return m, nil
+}
+
+// Rows returns the processes matching the filter in the chosen order.
+func (m Model) Rows() []db.Process {
+	rows := make([]db.Process, 0, len(m.processes))
+	filter := strings.ToLower(m.Filter.Value())
+
+	for _, process := range m.processes {
+		if filter == "" {
+			rows = append(rows, process)
+			continue
+		}
+
+		for _, column := range columns {
+			if strings.Contains(strings.ToLower(column.value(process)), filter) {
+				rows = append(rows, process)
+				break
+			}
+		}
+	}
+
+	less := columns[m.sortColumn].less
+	sort.SliceStable(rows, func(i, j int) bool {
+		if m.sortDesc {
+			return less(rows[j], rows[i])
+		}
+		return less(rows[i], rows[j])
+	})
+
+	return rows
+}
+
+// moveTo puts the cursor on a row and selects its process.
+func (m Model) moveTo(idx int, rows []db.Process) Model {
+	m.cursor = idx
+	if idx < len(rows) {
+		m.selected = rows[idx].ID
+	}
+	return m
+}
+
+// follow moves the cursor to the selected process after the rows changed,
+// or keeps it in place when that process is gone.
+func (m Model) follow() Model {
+	rows := m.Rows()
+
+	for idx, process := range rows {
+		if process.ID == m.selected {
+			m.cursor = idx
+			return m
+		}
+	}
+
+	return m.moveTo(min(m.cursor, max(len(rows)-1, 0)), rows)
+}
+
+func (m Model) Update(msg tea.Msg, active bool, conn *db.Connection) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tickMsg:
+		if !m.visible {
+			m.polling = false
+			return m, nil
+		}
+
+		if conn == nil || (*conn).GetConnection() == nil {
+			return m, m.tick()
+		}
+
+		return m, db.ProcessListCmd((*conn).GetConnection())
+
+	case db.ProcessListMsg:
+		m.err = msg.Err
+		if msg.Err == nil {
+			m.processes = msg.Processes
+			m.updatedAt = time.Now()
+			m = m.follow()
+		}
+
+		if !m.visible {
+			m.polling = false
+			return m, nil
+		}
+
+		return m, m.tick()
+
+	case db.KillResult:
+		kind := "query"
+		if msg.Connection {
+			kind = "connection"
+		}
+
+		if msg.Err != nil {
+			m.status = errorStyle().Render(fmt.Sprintf("Failed to kill %s %d: %v", kind, msg.ID, msg.Err))
+		} else {
+			m.status = fmt.Sprintf("Killed %s %d", kind, msg.ID)
+		}
+
+	case design.MouseMsg:
+		if m.filtering {
+			return m, nil
+		}
+
+		rows := m.Rows()
+		last := max(len(rows)-1, 0)
+
+		if scroll := msg.Scroll(); scroll != 0 {
+			m = m.moveTo(min(max(m.cursor+scroll, 0), last), rows)
+		}
+
+		// The first line is the header.
+		if _, y, ok := msg.Content(); ok && msg.Clicked() && y > 0 {
+			_, contentHeight := design.ContentSize(msg.Width, msg.Height)
+			visibleRows := contentHeight - 1 - lipgloss.Height(m.footer(rows))
+
+			if idx := design.ScrollStart(m.cursor, visibleRows) + y - 1; y-1 < visibleRows && idx < len(rows) {
+				m = m.moveTo(idx, rows)
+			}
+		}
+
+	case tea.KeyMsg:
+		if !active {
+			return m, nil
+		}
+
+		keys := keymap.Keys.Processes
+
+		if m.filtering {
+			if key.Matches(msg, keymap.Keys.Dialog.Cancel, keymap.Keys.Dialog.Submit) {
+				m.filtering = false
+				m.Filter.Blur()
+				return m, nil
+			}
+
+			var cmd tea.Cmd
+			m.Filter, cmd = m.Filter.Update(msg)
+			return m.follow(), cmd
+		}
+
+		rows := m.Rows()
+
+		switch {
+		case key.Matches(msg, keys.Up):
+			if m.cursor > 0 {
+				m = m.moveTo(m.cursor-1, rows)
+			}
+		case key.Matches(msg, keys.Down):
+			if m.cursor < len(rows)-1 {
+				m = m.moveTo(m.cursor+1, rows)
+			}
+		case key.Matches(msg, keys.Sort):
+			m.sortColumn = (m.sortColumn + 1) % len(columns)
+			m = m.follow()
+		case key.Matches(msg, keys.Reverse):
+			m.sortDesc = !m.sortDesc
+			m = m.follow()
+		case key.Matches(msg, keys.Filter):
+			m.filtering = true
+			return m, m.Filter.Focus()
+		case key.Matches(msg, keys.KillQuery, keys.KillConnection):
+			if m.cursor < len(rows) {
+				process := rows[m.cursor]
+				connection := key.Matches(msg, keys.KillConnection)
+				return m, func() tea.Msg {
+					return KillRequestMsg{Process: process, Connection: connection}
+				}
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func truncate(s string, width int) string {
+	s = strings.Join(strings.Fields(s), " ")
+
+	runes := []rune(s)
+	if width > 1 && len(runes) > width {
+		return string(runes[:width-1]) + "…"
+	}
+	return s
+}
+
+func renderRow(values []string, width int) string {
+	cells := make([]string, 0, len(values))
+
+	remaining := width
+	for idx, value := range values {
+		cellWidth := columns[idx].width
+		if cellWidth == 0 || cellWidth > remaining {
+			cellWidth = remaining
+		}
+		if cellWidth <= 0 {
+			break
+		}
+
+		cells = append(cells, lipgloss.NewStyle().Width(cellWidth).Render(truncate(value, cellWidth-1)))
+		remaining -= cellWidth
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, cells...)
+}
+
+func (m Model) footer(rows []db.Process) string {
+	footer := mutedStyle().Render(fmt.Sprintf(
+		"%d of %d sessions, every %s",
+		len(rows), len(m.processes), m.interval,
+	))
+	if m.filtering || m.Filter.Value() != "" {
+		footer = m.Filter.View()
+	}
+	if m.err != nil {
+		footer = errorStyle().Render(m.err.Error())
+	} else if m.status != "" {
+		footer = lipgloss.JoinVertical(lipgloss.Left, m.status, footer)
+	}
+
+	return footer
+}
+
+func (m Model) View(selected bool, width int, height int) string {
+	innerWidth, contentHeight := design.ContentSize(width, height)
+
+	headers := make([]string, len(columns))
+	for idx, column := range columns {
+		headers[idx] = column.title
+		if idx == m.sortColumn {
+			if m.sortDesc {
+				headers[idx] += " ↓"
+			} else {
+				headers[idx] += " ↑"
+			}
+		}
+	}
+
+	rows := m.Rows()
+
+	footer := m.footer(rows)
+
+	lines := []string{headerStyle().Render(renderRow(headers, innerWidth))}
+
+	visibleRows := contentHeight - 1 - lipgloss.Height(footer)
+	start := design.ScrollStart(m.cursor, visibleRows)
+
+	for idx := start; idx < len(rows) && idx-start < visibleRows; idx++ {
+		values := make([]string, len(columns))
+		for col, column := range columns {
+			values[col] = column.value(rows[idx])
+		}
+
+		line := renderRow(values, innerWidth)
+		if idx == m.cursor && selected {
+			line = theme.Selection().Render(line)
+		}
+		lines = append(lines, line)
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		lipgloss.JoinVertical(lipgloss.Left, lines...),
+		footer,
+	)
+
+	return design.Pane{Index: 6, Title: "Processes", Selected: selected}.Render(width, height, content)
+}
+
+func (m Model) ShortHelp() []key.Binding {
+	if m.filtering {
+		return []key.Binding{keymap.Keys.Dialog.Submit, keymap.Keys.Dialog.Cancel}
+	}
+
+	keys := keymap.Keys.Processes
+	return []key.Binding{keys.Sort, keys.Reverse, keys.Filter, keys.KillQuery, keys.KillConnection}
+}
+
+func (m Model) FullHelp() [][]key.Binding {
+	keys := keymap.Keys.Processes
+	return [][]key.Binding{
+		{keys.Up, keys.Down, keys.Sort, keys.Reverse, keys.Filter},
+		{keys.KillQuery, keys.KillConnection},
+	}
+}
@@ -0,0 +1,135 @@
+package processlist
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	db "gosuite/db"
+)
+
+func runes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func update(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	return m.Update(msg, true, nil)
+}
+
+func ids(rows []db.Process) []int64 {
+	out := make([]int64, len(rows))
+	for idx, row := range rows {
+		out[idx] = row.ID
+	}
+	return out
+}
+
+func equal(a []int64, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for idx := range a {
+		if a[idx] != b[idx] {
+			return false
+		}
+	}
+	return true
+}
+
+func loaded(processes ...db.Process) Model {
+	m, _ := update(InitModel(0), db.ProcessListMsg{Processes: processes})
+	return m
+}
+
+var processes = []db.Process{
+	{ID: 1, User: "app", Command: "Sleep", Time: 30},
+	{ID: 2, User: "root", Command: "Query", Time: 5, Info: "SELECT * FROM posts"},
+	{ID: 3, User: "app", Command: "Query", Time: 90, Info: "UPDATE posts SET title = 'x'"},
+}
+
+func TestRowsSortAndFilter(t *testing.T) {
+	m := loaded(processes...)
+
+	if got := ids(m.Rows()); !equal(got, []int64{3, 1, 2}) {
+		t.Errorf("Expected the longest running first, got %v", got)
+	}
+
+	m, _ = update(m, runes("S"))
+	if got := ids(m.Rows()); !equal(got, []int64{2, 1, 3}) {
+		t.Errorf("Expected the reversed order, got %v", got)
+	}
+
+	m, _ = update(m, runes("s"))
+	if got := ids(m.Rows()); !equal(got, []int64{1, 2, 3}) {
+		t.Errorf("Expected to sort by State, keeping the order of equal rows, got %v", got)
+	}
+
+	m, _ = update(m, runes("f"))
+	for _, r := range "posts" {
+		m, _ = update(m, runes(string(r)))
+	}
+	if got := ids(m.Rows()); !equal(got, []int64{2, 3}) {
+		t.Errorf("Expected the sessions running a query on posts, got %v", got)
+	}
+}
+
+func TestCursorFollowsProcess(t *testing.T) {
+	m := loaded(processes...)
+
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyDown})
+	if m.selected != 1 {
+		t.Fatalf("Expected process 1 to be selected, got %d", m.selected)
+	}
+
+	// Process 2 has been running longer since the last poll, so it moves up.
+	m, _ = update(m, db.ProcessListMsg{Processes: []db.Process{
+		{ID: 1, Time: 31},
+		{ID: 2, Time: 60},
+		{ID: 3, Time: 91},
+	}})
+
+	if m.cursor != 2 || m.selected != 1 {
+		t.Errorf("Expected the cursor to follow process 1 to row 2, got row %d on %d", m.cursor, m.selected)
+	}
+
+	m, _ = update(m, db.ProcessListMsg{Processes: []db.Process{{ID: 2, Time: 61}, {ID: 3, Time: 92}}})
+
+	if m.cursor != 1 || m.selected != 2 {
+		t.Errorf("Expected the cursor to stay on the last row when process 1 is gone, got row %d on %d", m.cursor, m.selected)
+	}
+
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyUp})
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyUp})
+	if m.cursor != 0 || m.selected != 3 {
+		t.Errorf("Expected the first row, got row %d on %d", m.cursor, m.selected)
+	}
+}
+
+func TestKillRequest(t *testing.T) {
+	m := loaded(processes...)
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyDown})
+
+	// A poll re-sorts the rows between selecting and killing.
+	m, _ = update(m, db.ProcessListMsg{Processes: []db.Process{
+		{ID: 1, Time: 100},
+		{ID: 2, Time: 5},
+		{ID: 3, Time: 90},
+	}})
+
+	_, cmd := update(m, runes("x"))
+	if cmd == nil {
+		t.Fatalf("Expected a kill request")
+	}
+	if msg, ok := cmd().(KillRequestMsg); !ok || msg.Process.ID != 1 || msg.Connection {
+		t.Errorf("Expected to kill the query of process 1, got %+v", msg)
+	}
+
+	_, cmd = update(m, runes("X"))
+	if msg, ok := cmd().(KillRequestMsg); !ok || msg.Process.ID != 1 || !msg.Connection {
+		t.Errorf("Expected to kill the connection of process 1, got %+v", msg)
+	}
+
+	if _, cmd := update(InitModel(0), runes("x")); cmd != nil {
+		t.Errorf("Expected no kill request without processes")
+	}
+}
@@ -0,0 +1,263 @@
+package status
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	db "gosuite/db"
+	design "gosuite/design"
+	theme "gosuite/theme"
+)
+
+const (
+	DefaultInterval = 5 * time.Second
+	historySize     = 120
+)
+
+var valueStyle = lipgloss.NewStyle().Width(34)
+
+func labelStyle() lipgloss.Style { return theme.Style(theme.Current.Muted).Width(18) }
+func sparkStyle() lipgloss.Style { return theme.Style(theme.Current.Accent) }
+func warnStyle() lipgloss.Style  { return theme.Style(theme.Current.Error) }
+
+const (
+	qpsMetric       = "qps"
+	slowMetric      = "slow"
+	lockWaitsMetric = "lock_waits"
+	connMetric      = "connections"
+	lagMetric       = "lag"
+)
+
+type tickMsg struct{}
+
+type Model struct {
+	interval time.Duration
+
+	current  *db.ServerStatus
+	previous *db.ServerStatus
+	err      error
+	history  map[string][]float64
+
+	visible bool
+	polling bool
+}
+
+func InitModel(interval time.Duration) Model {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	return Model{
+		interval: interval,
+		history:  make(map[string][]float64),
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) tick() tea.Cmd {
+	return tea.Tick(m.interval, func(time.Time) tea.Msg {
+		return tickMsg{}
+	})
+}
+
+// SetVisible starts refreshing when the pane is shown. Refreshing stops by
+// itself on the next tick after the pane is hidden.
+func (m Model) SetVisible(visible bool) (Model, tea.Cmd) {
+	m.visible = visible
+
+	if visible && !m.polling {
+		m.polling = true
+		return m, func() tea.Msg { return tickMsg{} }
+	}
+
+	return m, nil
+}
+
+func counter(status *db.ServerStatus, name string) float64 {
+	value, _ := strconv.ParseFloat(status.Variables[name], 64)
+	return value
+}
+
+// Rate is the per-second change of a status counter between two samples.
+// A counter that went backwards means the server restarted in between.
+func Rate(previous *db.ServerStatus, current *db.ServerStatus, name string) float64 {
+	if previous == nil || current == nil {
+		return 0
+	}
+
+	seconds := current.SampledAt.Sub(previous.SampledAt).Seconds()
+	delta := counter(current, name) - counter(previous, name)
+
+	if seconds <= 0 || delta < 0 {
+		return 0
+	}
+
+	return delta / seconds
+}
+
+// HitRatio is the share of buffer pool read requests served from memory
+// since the previous sample, or since startup for the first one.
+func HitRatio(previous *db.ServerStatus, current *db.ServerStatus) float64 {
+	requests := counter(current, "Innodb_buffer_pool_read_requests")
+	reads := counter(current, "Innodb_buffer_pool_reads")
+
+	if previous != nil {
+		deltaRequests := requests - counter(previous, "Innodb_buffer_pool_read_requests")
+		deltaReads := reads - counter(previous, "Innodb_buffer_pool_reads")
+
+		if deltaRequests > 0 && deltaReads >= 0 {
+			requests, reads = deltaRequests, deltaReads
+		}
+	}
+
+	if requests == 0 {
+		return 1
+	}
+
+	return 1 - reads/requests
+}
+
+func (m *Model) record(metric string, value float64) {
+	history := append(m.history[metric], value)
+	if len(history) > historySize {
+		history = history[len(history)-historySize:]
+	}
+	m.history[metric] = history
+}
+
+func (m Model) Update(msg tea.Msg, active bool, conn *db.Connection) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tickMsg:
+		if !m.visible {
+			m.polling = false
+			return m, nil
+		}
+
+		if conn == nil || (*conn).GetConnection() == nil {
+			return m, m.tick()
+		}
+
+		return m, db.ServerStatusCmd((*conn).GetConnection())
+
+	case db.Connection:
+		// Samples from another server can't be compared.
+		m.current = nil
+		m.previous = nil
+		m.history = make(map[string][]float64)
+
+	case db.StatusMsg:
+		m.err = msg.Err
+
+		if msg.Err == nil {
+			status := msg.Status
+			m.previous = m.current
+			m.current = &status
+
+			if m.previous != nil {
+				m.record(qpsMetric, Rate(m.previous, m.current, "Questions"))
+				m.record(slowMetric, Rate(m.previous, m.current, "Slow_queries"))
+				m.record(lockWaitsMetric, Rate(m.previous, m.current, "Innodb_row_lock_waits"))
+			}
+			m.record(connMetric, counter(m.current, "Threads_connected"))
+			if status.ReplicaLag.Valid {
+				m.record(lagMetric, float64(status.ReplicaLag.Int64))
+			}
+		}
+
+		if !m.visible {
+			m.polling = false
+			return m, nil
+		}
+
+		return m, m.tick()
+	}
+
+	return m, nil
+}
+
+func formatUptime(seconds float64) string {
+	d := time.Duration(seconds) * time.Second
+
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh %dm", days, hours, minutes)
+	}
+	return fmt.Sprintf("%dh %dm", hours, minutes)
+}
+
+func (m Model) row(label string, value string, metric string, width int) string {
+	spark := ""
+	if metric != "" {
+		spark = sparkStyle().Render(design.Sparkline(m.history[metric], width-52))
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, labelStyle().Render(label), valueStyle.Render(value), spark)
+}
+
+func (m Model) View(selected bool, width int, height int) string {
+	content := "Waiting for the first sample..."
+
+	if m.err != nil {
+		content = warnStyle().Render(m.err.Error())
+	} else if m.current != nil {
+		prev, cur := m.previous, m.current
+		innerWidth, _ := design.ContentSize(width, height)
+
+		lines := []string{
+			m.row("Version", cur.Version, "", innerWidth),
+			m.row("Uptime", formatUptime(counter(cur, "Uptime")), "", innerWidth),
+			m.row("Connections", fmt.Sprintf(
+				"%.0f connected · %.0f running · %.0f max",
+				counter(cur, "Threads_connected"),
+				counter(cur, "Threads_running"),
+				counter(cur, "Max_used_connections"),
+			), connMetric, innerWidth),
+			m.row("Queries", fmt.Sprintf("%.1f/s", Rate(prev, cur, "Questions")), qpsMetric, innerWidth),
+			m.row("Slow queries", fmt.Sprintf(
+				"%.0f total · %.2f/s",
+				counter(cur, "Slow_queries"),
+				Rate(prev, cur, "Slow_queries"),
+			), slowMetric, innerWidth),
+			m.row("Buffer pool hits", fmt.Sprintf("%.2f%%", HitRatio(prev, cur)*100), "", innerWidth),
+			m.row("Row lock waits", fmt.Sprintf(
+				"%.0f total · %.2f/s · %.0f now",
+				counter(cur, "Innodb_row_lock_waits"),
+				Rate(prev, cur, "Innodb_row_lock_waits"),
+				counter(cur, "Innodb_row_lock_current_waits"),
+			), lockWaitsMetric, innerWidth),
+		}
+
+		if cur.Replica {
+			lag := warnStyle().Render("replication stopped")
+			if cur.ReplicaLag.Valid {
+				lag = fmt.Sprintf("%ds", cur.ReplicaLag.Int64)
+			}
+			lines = append(lines, m.row("Replication lag", lag, lagMetric, innerWidth))
+		}
+
+		lines = append(lines, "", labelStyle().Render(fmt.Sprintf("Every %s", m.interval)))
+
+		content = lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	return design.Pane{Index: 7, Title: "Status", Selected: selected}.Render(width, height, content)
+}
+
+func (m Model) ShortHelp() []key.Binding {
+	return nil
+}
+
+func (m Model) FullHelp() [][]key.Binding {
+	return nil
+}
@@ -0,0 +1,54 @@
+package status
+
+import (
+	"testing"
+	"time"
+
+	db "gosuite/db"
+)
+
+func sample(at time.Time, variables map[string]string) *db.ServerStatus {
+	return &db.ServerStatus{Variables: variables, SampledAt: at}
+}
+
+func TestRate(t *testing.T) {
+	start := time.Now()
+
+	previous := sample(start, map[string]string{"Questions": "100"})
+	current := sample(start.Add(2*time.Second), map[string]string{"Questions": "300"})
+
+	if rate := Rate(previous, current, "Questions"); rate != 100 {
+		t.Errorf("Expected 100/s, got %v", rate)
+	}
+
+	restarted := sample(start.Add(4*time.Second), map[string]string{"Questions": "5"})
+
+	if rate := Rate(current, restarted, "Questions"); rate != 0 {
+		t.Errorf("Expected a restart to give 0/s, got %v", rate)
+	}
+
+	if rate := Rate(nil, current, "Questions"); rate != 0 {
+		t.Errorf("Expected no rate without a previous sample, got %v", rate)
+	}
+}
+
+func TestHitRatio(t *testing.T) {
+	start := time.Now()
+
+	previous := sample(start, map[string]string{
+		"Innodb_buffer_pool_read_requests": "1000",
+		"Innodb_buffer_pool_reads":         "500",
+	})
+	current := sample(start.Add(time.Second), map[string]string{
+		"Innodb_buffer_pool_read_requests": "2000",
+		"Innodb_buffer_pool_reads":         "510",
+	})
+
+	if ratio := HitRatio(nil, previous); ratio != 0.5 {
+		t.Errorf("Expected cumulative ratio 0.5, got %v", ratio)
+	}
+
+	if ratio := HitRatio(previous, current); ratio != 0.99 {
+		t.Errorf("Expected ratio between samples 0.99, got %v", ratio)
+	}
+}